@@ -0,0 +1,45 @@
+// Package api embeds the OpenAPI specification so the server can publish it and tests can
+// validate handler responses against it.
+package api
+
+import (
+	"context"
+	_ "embed"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SpecYAML is the OpenAPI 3 document for /api/v1, the source of truth edited by hand
+//
+//go:embed openapi.yaml
+var SpecYAML []byte
+
+// DocsHTML is a Redoc page rendering /api/openapi.json
+//
+//go:embed docs.html
+var DocsHTML []byte
+
+// Load parses and validates the specification
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(SpecYAML)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// SpecJSON returns the specification rendered as JSON, converted once on first use
+var SpecJSON = sync.OnceValues(func() ([]byte, error) {
+	doc, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.MarshalJSON()
+})
@@ -0,0 +1,127 @@
+// Command mindctl is an operator tool for inspecting and repairing Mindful Minutes data.
+// It connects to the database named by DATABASE_URL and reuses the server's repositories and services.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/user"
+
+	"gorm.io/gorm/logger"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/audit"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/postgres"
+)
+
+const usage = `usage: mindctl [-o table|json] <command> [flags]
+
+commands:
+  user show          look up users by --email, --clerk-id or --id
+  user delete        permanently delete a user and all their data (GDPR)
+  user role          set a user's role with --role user|admin
+  sessions list      list a user's sessions, including deleted ones
+  sessions restore   restore a deleted session
+  rollups recompute  regenerate a user's cached year reviews and achievements
+  webhooks list      list stored webhook events
+  webhooks replay    re-apply a stored webhook event
+  stats              print service-wide totals`
+
+// command runs a subcommand with its remaining arguments
+type command func(ctx context.Context, app *app, args []string) error
+
+var commands = map[string]command{
+	"user show":         userShow,
+	"user delete":       userDelete,
+	"user role":         userRole,
+	"sessions list":     sessionsList,
+	"sessions restore":  sessionsRestore,
+	"rollups recompute": rollupsRecompute,
+	"webhooks list":     webhooksList,
+	"webhooks replay":   webhooksReplay,
+	"stats":             stats,
+}
+
+// app holds the dependencies shared by every command
+type app struct {
+	repos *repository.Repositories
+	out   *printer
+}
+
+func main() {
+	err := run(context.Background(), os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "mindctl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("mindctl", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprintln(flags.Output(), usage) }
+	format := flags.String("o", "table", "output format: table or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != "table" && *format != "json" {
+		return fmt.Errorf("unknown output format %q", *format)
+	}
+
+	cmd, rest, err := lookupCommand(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Keep SQL logging off stdout so JSON output stays parseable
+	db, err := database.Connect(cfg.Database.URL, logger.Default.LogMode(logger.Silent))
+	if err != nil {
+		return err
+	}
+	defer database.Close(db) //nolint:errcheck
+
+	// Repairs are recorded in the affected users' audit logs under the operator's OS user name
+	operator := "unknown"
+	if current, err := user.Current(); err == nil {
+		operator = current.Username
+	}
+	ctx = audit.WithActor(ctx, audit.ActorOperator, operator)
+
+	return cmd(ctx, &app{
+		repos: audit.Wrap(postgres.NewRepositories(db)),
+		out:   &printer{format: *format, w: os.Stdout},
+	}, rest)
+}
+
+// lookupCommand matches one- and two-word command names against the arguments
+func lookupCommand(args []string) (command, []string, error) {
+	if len(args) == 0 {
+		return nil, nil, errors.New(usage)
+	}
+
+	if cmd, ok := commands[args[0]]; ok {
+		return cmd, args[1:], nil
+	}
+
+	if len(args) > 1 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return cmd, args[2:], nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("unknown command %q\n%s", args[0], usage)
+}
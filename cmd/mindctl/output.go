@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// printer renders command results as an aligned table or as JSON
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+// print writes value as indented JSON, or the given header and rows as a table
+func (p *printer) print(value any, header []string, rows [][]string) error {
+	if p.format == "json" {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+	}
+
+	w := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	return w.Flush()
+}
+
+// formatTime renders a timestamp for tables, or a dash when it is unset
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// valueOr dereferences s, or returns a dash when it is nil or empty
+func valueOr(s *string) string {
+	if s == nil || *s == "" {
+		return "-"
+	}
+
+	return *s
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
+)
+
+// recomputeResult reports what rollups recompute regenerated for a user
+type recomputeResult struct {
+	UserID          string   `json:"user_id"`
+	Years           []int    `json:"years"`
+	NewAchievements []string `json:"new_achievements"`
+}
+
+func rollupsRecompute(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("rollups recompute", flag.ContinueOnError)
+	search := userFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	user, err := findUser(ctx, app, search)
+	if err != nil {
+		return err
+	}
+
+	// Drop every cached review, then regenerate one for each year the user has sessions in
+	if err := app.repos.YearReviews.DeleteForUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	// Reviews cover years in the user's timezone
+	preferences, err := app.repos.Preferences.Get(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	totals, err := app.repos.Sessions.DailyTotals(ctx, user.ID, time.Time{}, time.Time{}, preferences.Location())
+	if err != nil {
+		return err
+	}
+
+	result := recomputeResult{UserID: user.ID, Years: []int{}, NewAchievements: []string{}}
+	reviews := services.NewReviewService(app.repos.Sessions, app.repos.YearReviews, app.repos.Preferences)
+	for _, total := range totals {
+		year := total.Day.Year()
+		if len(result.Years) > 0 && result.Years[len(result.Years)-1] == year {
+			continue
+		}
+
+		if _, err := reviews.GetYearReview(ctx, user.ID, year); err != nil {
+			return err
+		}
+		result.Years = append(result.Years, year)
+	}
+
+	// Award any badges missed while evaluation was failing or before a badge existed
+	achievements := services.NewAchievementService(app.repos.Sessions, app.repos.Achievements, app.repos.Preferences)
+	awarded, err := achievements.EvaluateAchievements(ctx, user.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, achievement := range awarded {
+		result.NewAchievements = append(result.NewAchievements, achievement.BadgeKey)
+	}
+
+	years := make([]string, len(result.Years))
+	for i, year := range result.Years {
+		years[i] = strconv.Itoa(year)
+	}
+
+	return app.out.print(result, []string{"USER ID", "YEARS", "NEW ACHIEVEMENTS"}, [][]string{{
+		user.ID,
+		strings.Join(years, ","),
+		strings.Join(result.NewAchievements, ","),
+	}})
+}
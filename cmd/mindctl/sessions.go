@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strconv"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
+)
+
+func sessionsList(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("sessions list", flag.ContinueOnError)
+	search := userFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	user, err := findUser(ctx, app, search)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := app.repos.Sessions.ListWithDeleted(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return printSessions(app, sessions)
+}
+
+func sessionsRestore(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("sessions restore", flag.ContinueOnError)
+	search := userFlags(flags)
+	sessionID := flags.Uint("session", 0, "ID of the deleted session to restore")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *sessionID == 0 {
+		return errors.New("--session is required")
+	}
+
+	user, err := findUser(ctx, app, search)
+	if err != nil {
+		return err
+	}
+
+	session, err := app.repos.Sessions.Restore(ctx, user.ID, *sessionID)
+	if err != nil {
+		return err
+	}
+
+	// The restored session changes that year's totals
+	reviews := services.NewReviewService(app.repos.Sessions, app.repos.YearReviews, app.repos.Preferences)
+	if err := reviews.InvalidateYearReview(ctx, user.ID, session.CreatedAt); err != nil {
+		return err
+	}
+
+	return printSessions(app, []models.Session{*session})
+}
+
+// printSessions renders sessions with their soft-delete state
+func printSessions(app *app, sessions []models.Session) error {
+	rows := make([][]string, 0, len(sessions))
+	for _, session := range sessions {
+		var deletedAt *time.Time
+		if session.DeletedAt.Valid {
+			deletedAt = &session.DeletedAt.Time
+		}
+
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(session.ID), 10),
+			session.SessionType,
+			strconv.Itoa(session.DurationSeconds / 60),
+			formatTime(&session.CreatedAt),
+			formatTime(deletedAt),
+		})
+	}
+
+	return app.out.print(sessions, []string{"ID", "TYPE", "MINUTES", "CREATED", "DELETED"}, rows)
+}
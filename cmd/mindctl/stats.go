@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// statsResult is the JSON shape of the stats command
+type statsResult struct {
+	Users           int     `json:"users"`
+	DeletedUsers    int     `json:"deleted_users"`
+	Sessions        int     `json:"sessions"`
+	DeletedSessions int     `json:"deleted_sessions"`
+	TotalHours      float64 `json:"total_hours"`
+	Achievements    int     `json:"achievements"`
+	WebhookEvents   int     `json:"webhook_events"`
+}
+
+func stats(ctx context.Context, app *app, _ []string) error {
+	totals, err := app.repos.Stats.Totals(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := statsResult{
+		Users:           totals.Users,
+		DeletedUsers:    totals.DeletedUsers,
+		Sessions:        totals.Sessions,
+		DeletedSessions: totals.DeletedSessions,
+		TotalHours:      float64(totals.TotalSeconds) / 3600.0,
+		Achievements:    totals.Achievements,
+		WebhookEvents:   totals.WebhookEvents,
+	}
+
+	return app.out.print(result, []string{"METRIC", "VALUE"}, [][]string{
+		{"users", strconv.Itoa(result.Users)},
+		{"deleted_users", strconv.Itoa(result.DeletedUsers)},
+		{"sessions", strconv.Itoa(result.Sessions)},
+		{"deleted_sessions", strconv.Itoa(result.DeletedSessions)},
+		{"total_hours", fmt.Sprintf("%.1f", result.TotalHours)},
+		{"achievements", strconv.Itoa(result.Achievements)},
+		{"webhook_events", strconv.Itoa(result.WebhookEvents)},
+	})
+}
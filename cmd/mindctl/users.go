@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+// userFlags registers the flags used to identify a user on a command's flag set
+func userFlags(flags *flag.FlagSet) *repository.UserSearch {
+	var search repository.UserSearch
+	flags.StringVar(&search.ID, "id", "", "user ID")
+	flags.StringVar(&search.Email, "email", "", "user email address")
+	flags.StringVar(&search.ClerkUserID, "clerk-id", "", "Clerk user ID")
+
+	return &search
+}
+
+// findUser resolves the search to exactly one user, including soft-deleted users
+func findUser(ctx context.Context, app *app, search *repository.UserSearch) (*models.User, error) {
+	if *search == (repository.UserSearch{}) {
+		return nil, errors.New("one of --id, --email or --clerk-id is required")
+	}
+
+	users, err := app.repos.Users.Search(ctx, *search)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(users) {
+	case 0:
+		return nil, errors.New("no matching user")
+	case 1:
+		return &users[0], nil
+	default:
+		return nil, fmt.Errorf("%d users match, narrow the search with --id", len(users))
+	}
+}
+
+func userShow(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("user show", flag.ContinueOnError)
+	search := userFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *search == (repository.UserSearch{}) {
+		return errors.New("one of --id, --email or --clerk-id is required")
+	}
+
+	users, err := app.repos.Users.Search(ctx, *search)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(users))
+	for _, user := range users {
+		name := strings.TrimSpace(valueOr(user.FirstName) + " " + valueOr(user.LastName))
+		var deletedAt *time.Time
+		if user.DeletedAt.Valid {
+			deletedAt = &user.DeletedAt.Time
+		}
+
+		rows = append(rows, []string{user.ID, user.ClerkUserID, user.Email, name, user.Role, formatTime(&user.CreatedAt), formatTime(deletedAt)})
+	}
+
+	return app.out.print(users, []string{"ID", "CLERK ID", "EMAIL", "NAME", "ROLE", "CREATED", "DELETED"}, rows)
+}
+
+// userRole grants or revokes a role. With Clerk the role in the user's public metadata replaces
+// this on the next user.updated webhook, so a role removed there is removed here too.
+func userRole(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("user role", flag.ContinueOnError)
+	search := userFlags(flags)
+	role := flags.String("role", "", "role to assign: user or admin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if !models.IsValidRole(*role) {
+		return fmt.Errorf("--role must be %s or %s", models.RoleUser, models.RoleAdmin)
+	}
+
+	user, err := findUser(ctx, app, search)
+	if err != nil {
+		return err
+	}
+
+	user.Role = *role
+	if err := app.repos.Users.Update(ctx, user); err != nil {
+		return err
+	}
+
+	result := map[string]string{"user_id": user.ID, "role": user.Role}
+
+	return app.out.print(result, []string{"USER ID", "ROLE"}, [][]string{{user.ID, user.Role}})
+}
+
+func userDelete(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("user delete", flag.ContinueOnError)
+	search := userFlags(flags)
+	confirm := flags.Bool("confirm", false, "confirm permanent deletion")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	user, err := findUser(ctx, app, search)
+	if err != nil {
+		return err
+	}
+
+	if !*confirm {
+		return fmt.Errorf("refusing to permanently delete user %s (%s) without --confirm", user.ID, user.Email)
+	}
+
+	if err := app.repos.Users.HardDelete(ctx, user.ID); err != nil {
+		return err
+	}
+
+	result := map[string]string{"user_id": user.ID, "status": "deleted"}
+
+	return app.out.print(result, []string{"USER ID", "STATUS"}, [][]string{{user.ID, "deleted"}})
+}
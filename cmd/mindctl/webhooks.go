@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+)
+
+func webhooksList(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("webhooks list", flag.ContinueOnError)
+	limit := flags.Int("limit", 20, "maximum number of events to list")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	events, err := app.repos.WebhookEvents.List(ctx, *limit)
+	if err != nil {
+		return err
+	}
+
+	return printWebhookEvents(app, events)
+}
+
+func webhooksReplay(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("webhooks replay", flag.ContinueOnError)
+	id := flags.Uint("id", 0, "ID of the stored webhook event")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *id == 0 {
+		return errors.New("--id is required")
+	}
+
+	stored, err := app.repos.WebhookEvents.FindByID(ctx, *id)
+	if err != nil {
+		return err
+	}
+
+	var event auth.ClerkWebhookEvent
+	if err := json.Unmarshal([]byte(stored.Payload), &event); err != nil {
+		return fmt.Errorf("stored payload is not a valid event: %w", err)
+	}
+
+	_, replayErr := auth.ApplyWebhookEvent(ctx, app.repos.Users, event)
+	auth.RecordWebhookOutcome(ctx, app.repos.WebhookEvents, stored, replayErr)
+
+	if err := printWebhookEvents(app, []models.WebhookEvent{*stored}); err != nil {
+		return err
+	}
+
+	return replayErr
+}
+
+// printWebhookEvents renders stored events without their payloads
+func printWebhookEvents(app *app, events []models.WebhookEvent) error {
+	rows := make([][]string, 0, len(events))
+	for _, event := range events {
+		errorMessage := event.Error
+		if errorMessage == "" {
+			errorMessage = "-"
+		}
+
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(event.ID), 10),
+			event.EventType,
+			event.ClerkUserID,
+			formatTime(&event.ReceivedAt),
+			formatTime(event.ProcessedAt),
+			errorMessage,
+		})
+	}
+
+	return app.out.print(events, []string{"ID", "TYPE", "CLERK ID", "RECEIVED", "PROCESSED", "ERROR"}, rows)
+}
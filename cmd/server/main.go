@@ -1,19 +1,116 @@
 package main
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	// Embed the timezone database so user timezones resolve on images without one
+	_ "time/tzdata"
 
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/http"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/logging"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/postgres"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
+	"github.com/mindful-minutes/mindful-minutes-api/migrations"
 )
 
 func main() {
-	server, err := http.NewServer()
+	if err := run(); err != nil {
+		slog.Error("server exited", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+// run starts the server, or a migrate subcommand, and returns once it has fully stopped.
+// Deferred cleanup runs before main exits so the database is always closed last.
+func run() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	level, err := logging.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		return err
+	}
+	logger := logging.New(os.Stdout, cfg.Log.Format, level)
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	// Runs after the server stops so spans from draining requests are flushed
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("failed to flush traces", slog.String("error", err.Error()))
+		}
+	}()
+
+	db, err := database.Connect(cfg.Database.URL, logging.NewGormLogger(logger, cfg.Log.SlowQueryThreshold))
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() {
+		if err := database.Close(db); err != nil {
+			slog.Error("failed to close database", slog.String("error", err.Error()))
+		}
+	}()
+
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatal("Failed to create server:", err)
+		return fmt.Errorf("failed to access database pool: %w", err)
+	}
+	if err := metrics.RegisterDB(sqlDB, "postgres"); err != nil {
+		return fmt.Errorf("failed to register database metrics: %w", err)
+	}
+
+	migrator, err := database.NewMigrator(db, migrations.FS)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
 	}
 
-	log.Println("Starting server...")
-	if err := server.Start(); err != nil {
-		log.Fatal("Failed to start server:", err)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), migrator, os.Args[2:]); err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+
+		return nil
+	}
+
+	if cfg.Database.AutoMigrate {
+		slog.Info("applying database migrations")
+		if err := migrator.Up(context.Background()); err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
 	}
+
+	authProvider, err := auth.NewProvider(context.Background(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to set up auth provider: %w", err)
+	}
+
+	server := http.NewServer(cfg, postgres.NewRepositories(db), func(ctx context.Context) error {
+		return database.IsHealthy(db)
+	}, http.WithAuthProvider(authProvider))
+
+	// SIGTERM is sent by Kubernetes during rollouts, SIGINT by Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := server.Start(ctx); err != nil {
+		return fmt.Errorf("server error: %w", err)
+	}
+
+	return nil
 }
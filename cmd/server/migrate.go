@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up              apply all pending migrations
+  down [steps]    roll back the given number of migrations (default 1)
+  to <version>    migrate up or down to the given version (0 rolls back everything)
+  force <version> record the version as applied without running SQL
+  status          show the current version and every migration`
+
+// runMigrate executes a migrate subcommand against the database
+func runMigrate(ctx context.Context, migrator *database.Migrator, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return fmt.Errorf("steps must be a positive number: %s", args[1])
+			}
+		}
+
+		return migrator.Down(ctx, steps)
+	case "to", "force":
+		if len(args) < 2 {
+			return fmt.Errorf("%s requires a version\n%s", args[0], migrateUsage)
+		}
+
+		version, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("version must be a number: %s", args[1])
+		}
+
+		if args[0] == "force" {
+			return migrator.Force(ctx, uint(version))
+		}
+
+		return migrator.To(ctx, uint(version))
+	case "status":
+		return printMigrationStatus(ctx, migrator)
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+}
+
+// printMigrationStatus writes the current schema version and each migration's state to stdout
+func printMigrationStatus(ctx context.Context, migrator *database.Migrator) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Current version: %d", status.Version)
+	if status.Dirty {
+		fmt.Fprint(w, " (dirty)")
+	}
+	fmt.Fprint(w, "\n\nVERSION\tNAME\tSTATUS\n")
+
+	for _, migration := range status.Migrations {
+		state := "pending"
+		if migration.Applied {
+			state = "applied"
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\n", migration.Version, migration.Name, state)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,148 @@
+package apierror
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/logging"
+)
+
+// ContentType is the media type of problem responses (RFC 7807)
+const ContentType = "application/problem+json"
+
+// Code is a stable, machine-readable error identifier clients can switch on. Codes are part of
+// the public API: add new ones freely but never rename or reuse them.
+type Code string
+
+const (
+	CodeInternal         Code = "INTERNAL_ERROR"
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+	CodeRouteNotFound    Code = "ROUTE_NOT_FOUND"
+	CodeMethodNotAllowed Code = "METHOD_NOT_ALLOWED"
+	CodeRateLimited      Code = "RATE_LIMITED"
+
+	CodeMissingAuthorization Code = "MISSING_AUTHORIZATION"
+	CodeInvalidAuthorization Code = "INVALID_AUTHORIZATION"
+	CodeInvalidToken         Code = "INVALID_TOKEN"
+	CodeUserNotFound         Code = "USER_NOT_FOUND"
+	CodeInsufficientScope    Code = "INSUFFICIENT_SCOPE"
+	CodeInsufficientRole     Code = "INSUFFICIENT_ROLE"
+
+	CodeInvalidSessionType Code = "INVALID_SESSION_TYPE"
+	CodeInvalidSessionID   Code = "INVALID_SESSION_ID"
+	CodeSessionNotFound    Code = "SESSION_NOT_FOUND"
+	CodeInvalidYear        Code = "INVALID_YEAR"
+
+	CodeInvalidScope         Code = "INVALID_SCOPE"
+	CodeAccessTokenNotFound  Code = "ACCESS_TOKEN_NOT_FOUND"
+	CodeInvalidAccessTokenID Code = "INVALID_ACCESS_TOKEN_ID"
+
+	CodeInvalidReminderID    Code = "INVALID_REMINDER_ID"
+	CodeReminderNotFound     Code = "REMINDER_NOT_FOUND"
+	CodeReminderLimitReached Code = "REMINDER_LIMIT_REACHED"
+
+	CodeWebhookNotConfigured    Code = "WEBHOOK_NOT_CONFIGURED"
+	CodeMissingWebhookSignature Code = "MISSING_WEBHOOK_SIGNATURE"
+	CodeInvalidWebhookSignature Code = "INVALID_WEBHOOK_SIGNATURE"
+	CodeInvalidWebhookPayload   Code = "INVALID_WEBHOOK_PAYLOAD"
+)
+
+// FieldError describes why a single request field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an API error carrying everything needed to render a problem response.
+// Cause is logged for internal errors but never sent to the client.
+type Error struct {
+	Status int
+	Code   Code
+	Detail string
+	Fields []FieldError
+	Cause  error
+}
+
+// New returns a client error whose detail is safe to show to the caller
+func New(status int, code Code, detail string) *Error {
+	return &Error{Status: status, Code: code, Detail: detail}
+}
+
+// Internal wraps an unexpected failure. The detail describes the failed operation; the cause is only logged.
+func Internal(detail string, cause error) *Error {
+	return &Error{Status: http.StatusInternalServerError, Code: CodeInternal, Detail: detail, Cause: cause}
+}
+
+// WithCause attaches the underlying error, e.g. for errors.Is checks, without exposing it
+func (e *Error) WithCause(cause error) *Error {
+	e.Cause = cause
+
+	return e
+}
+
+// WithFields attaches field-level validation messages
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	e.Fields = append(e.Fields, fields...)
+
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.Cause == nil {
+		return e.Detail
+	}
+
+	return e.Detail + ": " + e.Cause.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Problem is the RFC 7807 response body. Code, RequestID and Errors are extension members.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      Code         `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// Abort writes err as a problem response and stops the handler chain. Errors that aren't
+// *Error are treated as internal. Causes of internal errors are logged, never sent to the client,
+// so database and upstream messages don't leak.
+func Abort(c *gin.Context, err error) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		apiErr = Internal("An unexpected error occurred", err)
+	}
+
+	ctx := c.Request.Context()
+	problem := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(apiErr.Status),
+		Status:    apiErr.Status,
+		Detail:    apiErr.Detail,
+		Instance:  c.Request.URL.Path,
+		Code:      apiErr.Code,
+		RequestID: logging.RequestID(ctx),
+		Errors:    apiErr.Fields,
+	}
+
+	if apiErr.Status >= http.StatusInternalServerError && apiErr.Cause != nil {
+		slog.ErrorContext(ctx, "request failed",
+			slog.String("code", string(apiErr.Code)),
+			slog.String("detail", apiErr.Detail),
+			slog.String("error", apiErr.Cause.Error()),
+		)
+	}
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(apiErr.Status, problem)
+}
@@ -0,0 +1,168 @@
+package apierror_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/logging"
+)
+
+type createRequest struct {
+	DurationSeconds int    `json:"duration_seconds" binding:"required,min=1"`
+	SessionType     string `json:"session_type" binding:"required,oneof=metta walking"`
+}
+
+func serve(router *gin.Engine, method, path, body string) (*httptest.ResponseRecorder, apierror.Problem) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var problem apierror.Problem
+	_ = json.Unmarshal(w.Body.Bytes(), &problem)
+
+	return w, problem
+}
+
+func TestAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&logs, "json", slog.LevelInfo))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	router := gin.New()
+	router.Use(logging.RequestIDMiddleware())
+	router.GET("/sessions/:id", func(c *gin.Context) {
+		apierror.Abort(c, apierror.New(http.StatusNotFound, apierror.CodeSessionNotFound, "Session not found"))
+	})
+	router.GET("/internal", func(c *gin.Context) {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve sessions", errors.New("pq: relation \"sessions\" does not exist")))
+	})
+	router.GET("/plain", func(c *gin.Context) {
+		apierror.Abort(c, errors.New("boom"))
+	})
+
+	t.Run("write problem json with code and request id", func(t *testing.T) {
+		w, problem := serve(router, "GET", "/sessions/42", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, apierror.ContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "about:blank", problem.Type)
+		assert.Equal(t, "Not Found", problem.Title)
+		assert.Equal(t, http.StatusNotFound, problem.Status)
+		assert.Equal(t, "Session not found", problem.Detail)
+		assert.Equal(t, "/sessions/42", problem.Instance)
+		assert.Equal(t, apierror.CodeSessionNotFound, problem.Code)
+		assert.Equal(t, w.Header().Get(logging.RequestIDHeader), problem.RequestID)
+	})
+
+	t.Run("log internal error cause without exposing it", func(t *testing.T) {
+		logs.Reset()
+
+		w, problem := serve(router, "GET", "/internal", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, apierror.CodeInternal, problem.Code)
+		assert.Equal(t, "Failed to retrieve sessions", problem.Detail)
+		assert.NotContains(t, w.Body.String(), "relation")
+		assert.Contains(t, logs.String(), "relation")
+	})
+
+	t.Run("treat unknown errors as internal", func(t *testing.T) {
+		w, problem := serve(router, "GET", "/plain", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, apierror.CodeInternal, problem.Code)
+		assert.NotContains(t, w.Body.String(), "boom")
+	})
+}
+
+func TestValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/sessions", func(c *gin.Context) {
+		var req createRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Abort(c, apierror.Validation(err))
+
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	t.Run("translate validator errors to json field names", func(t *testing.T) {
+		w, problem := serve(router, "POST", "/sessions", `{"duration_seconds": 0, "session_type": "yoga"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, apierror.CodeValidationFailed, problem.Code)
+		assert.Equal(t, []apierror.FieldError{
+			{Field: "duration_seconds", Message: "is required"},
+			{Field: "session_type", Message: "must be one of metta, walking"},
+		}, problem.Errors)
+	})
+
+	t.Run("report field with wrong json type", func(t *testing.T) {
+		_, problem := serve(router, "POST", "/sessions", `{"duration_seconds": "ten", "session_type": "metta"}`)
+
+		assert.Equal(t, apierror.CodeValidationFailed, problem.Code)
+		assert.Equal(t, []apierror.FieldError{{Field: "duration_seconds", Message: "must be of type int"}}, problem.Errors)
+	})
+
+	t.Run("report malformed json without field errors", func(t *testing.T) {
+		_, problem := serve(router, "POST", "/sessions", `{"duration_seconds":`)
+
+		assert.Equal(t, apierror.CodeValidationFailed, problem.Code)
+		assert.Equal(t, "Request body is not valid JSON", problem.Detail)
+		assert.Empty(t, problem.Errors)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var logs bytes.Buffer
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	router.Use(apierror.Recovery(logging.New(&logs, "json", slog.LevelInfo)))
+	router.NoRoute(apierror.NoRoute)
+	router.NoMethod(apierror.NoMethod)
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	t.Run("log panic and return internal error problem", func(t *testing.T) {
+		w, problem := serve(router, "GET", "/panic", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, apierror.CodeInternal, problem.Code)
+		assert.Contains(t, logs.String(), "panic recovered")
+		assert.NotContains(t, w.Body.String(), "boom")
+	})
+
+	t.Run("return route not found for unknown paths", func(t *testing.T) {
+		w, problem := serve(router, "GET", "/nowhere", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, apierror.CodeRouteNotFound, problem.Code)
+	})
+
+	t.Run("return method not allowed for known paths", func(t *testing.T) {
+		w, problem := serve(router, "DELETE", "/panic", "")
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, apierror.CodeMethodNotAllowed, problem.Code)
+	})
+}
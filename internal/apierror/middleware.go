@@ -0,0 +1,27 @@
+package apierror
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery turns panics into INTERNAL_ERROR responses and logs them with the request's fields
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		logger.ErrorContext(c.Request.Context(), "panic recovered", slog.Any("panic", recovered))
+		Abort(c, New(http.StatusInternalServerError, CodeInternal, "An unexpected error occurred"))
+	})
+}
+
+// NoRoute answers requests for unknown paths
+func NoRoute(c *gin.Context) {
+	Abort(c, New(http.StatusNotFound, CodeRouteNotFound, "No route matches "+c.Request.URL.Path))
+}
+
+// NoMethod answers requests whose path exists but not for the request method
+func NoMethod(c *gin.Context) {
+	Abort(c, New(http.StatusMethodNotAllowed, CodeMethodNotAllowed, c.Request.Method+" is not allowed on "+c.Request.URL.Path))
+}
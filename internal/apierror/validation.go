@@ -0,0 +1,72 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Report JSON field names rather than Go struct field names in validation errors
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+// Validation translates a request binding error into a VALIDATION_FAILED error with one entry
+// per rejected field. Malformed JSON is reported without field entries.
+func Validation(err error) *Error {
+	apiErr := New(http.StatusBadRequest, CodeValidationFailed, "Invalid request data").WithCause(err)
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, fieldErr := range validationErrs {
+			apiErr.Fields = append(apiErr.Fields, FieldError{Field: fieldErr.Field(), Message: validationMessage(fieldErr)})
+		}
+	case errors.As(err, &typeErr):
+		apiErr.Fields = append(apiErr.Fields, FieldError{Field: typeErr.Field, Message: "must be of type " + typeErr.Type.String()})
+	default:
+		apiErr.Detail = "Request body is not valid JSON"
+	}
+
+	return apiErr
+}
+
+func validationMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return fmt.Sprintf("must be at least %s", fieldErr.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", fieldErr.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", strings.Join(strings.Fields(fieldErr.Param()), ", "))
+	case "email":
+		return "must be a valid email address"
+	case "unique":
+		return "must not contain duplicates"
+	default:
+		return "is invalid"
+	}
+}
@@ -0,0 +1,224 @@
+// Package audit records who changed what in a user's account. Writes go through repository
+// decorators that add an append-only audit event for each change, attributed to the actor and
+// client IP carried in the request context. Reads by support staff are recorded with RecordAccess.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/logging"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+// Actor types say who made a change. The actor ID's meaning depends on the type.
+const (
+	// ActorUser is the account owner with a session token; the ID is their user ID
+	ActorUser = "user"
+	// ActorAccessToken is one of the owner's personal access tokens; the ID is the token ID
+	ActorAccessToken = "access_token"
+	// ActorAdmin is support staff using the admin API; the ID is their user ID
+	ActorAdmin = "admin"
+	// ActorWebhook is a webhook delivery; the ID is the stored webhook event ID
+	ActorWebhook = "webhook"
+	// ActorAuthProvider is the identity provider when a user is provisioned on first sign-in; the ID is its name
+	ActorAuthProvider = "auth_provider"
+	// ActorOperator is someone running mindctl; the ID is their OS user name
+	ActorOperator = "operator"
+	// ActorSystem is used when nothing set an actor
+	ActorSystem = "system"
+)
+
+// source holds the actor and client IP of a request. It is stored by pointer so authentication,
+// which runs after Middleware, can fill in the actor.
+type source struct {
+	mu        sync.RWMutex
+	actorType string
+	actorID   string
+	ip        string
+}
+
+type sourceKey struct{}
+
+// Middleware stores the client IP in the request context for the events the request records
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := context.WithValue(c.Request.Context(), sourceKey{}, &source{ip: c.ClientIP()})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// WithActor returns a context whose changes are attributed to the given actor, for work done
+// outside an HTTP request such as mindctl commands
+func WithActor(ctx context.Context, actorType, actorID string) context.Context {
+	return context.WithValue(ctx, sourceKey{}, &source{actorType: actorType, actorID: actorID})
+}
+
+// SetActor attributes the request's changes to the given actor.
+// It is a no-op for contexts that did not pass through Middleware or WithActor.
+func SetActor(ctx context.Context, actorType, actorID string) {
+	s, ok := ctx.Value(sourceKey{}).(*source)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.actorType = actorType
+	s.actorID = actorID
+}
+
+// Actor returns the actor stored in the context, or ActorSystem if there is none
+func Actor(ctx context.Context) (actorType, actorID string) {
+	s, ok := ctx.Value(sourceKey{}).(*source)
+	if !ok {
+		return ActorSystem, ""
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.actorType == "" {
+		return ActorSystem, ""
+	}
+
+	return s.actorType, s.actorID
+}
+
+// clientIP returns the IP stored by Middleware, if any
+func clientIP(ctx context.Context) string {
+	s, ok := ctx.Value(sourceKey{}).(*source)
+	if !ok {
+		return ""
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.ip
+}
+
+// change describes one audited write. Before is nil for creations and After for deletions.
+type change struct {
+	userID     string
+	action     string
+	targetType string
+	targetID   string
+	before     any
+	after      any
+}
+
+// record stores an event for the change. The write it describes has already happened, so a
+// failure is logged rather than returned. Changes that leave every field as it was are skipped.
+func record(ctx context.Context, events repository.AuditEventRepository, ch change) {
+	changes, err := Diff(ch.before, ch.after)
+	if err == nil && len(changes) == 0 {
+		return
+	}
+
+	if err == nil {
+		err = store(ctx, events, ch, changes)
+	}
+	if err != nil {
+		logFailure(ctx, ch, err)
+	}
+}
+
+// RecordAccess stores an event saying the actor viewed data in a user's account, such as
+// support staff opening it through the admin API. Reads change nothing, so the event has no
+// changes. Like other events, a failure is logged rather than returned.
+func RecordAccess(ctx context.Context, events repository.AuditEventRepository, userID, action, targetType, targetID string) {
+	ch := change{userID: userID, action: action, targetType: targetType, targetID: targetID}
+	if err := store(ctx, events, ch, map[string]models.AuditChange{}); err != nil {
+		logFailure(ctx, ch, err)
+	}
+}
+
+func store(ctx context.Context, events repository.AuditEventRepository, ch change, changes map[string]models.AuditChange) error {
+	event := &models.AuditEvent{
+		UserID:     ch.userID,
+		Action:     ch.action,
+		TargetType: ch.targetType,
+		TargetID:   ch.targetID,
+		Changes:    changes,
+		RequestID:  logging.RequestID(ctx),
+		IP:         clientIP(ctx),
+	}
+	event.ActorType, event.ActorID = Actor(ctx)
+
+	return events.Create(ctx, event)
+}
+
+func logFailure(ctx context.Context, ch change, err error) {
+	slog.ErrorContext(ctx, "failed to record audit event",
+		slog.String("action", ch.action),
+		slog.String("target_id", ch.targetID),
+		slog.String("error", err.Error()),
+	)
+}
+
+// ignoredFields are left out of diffs: updated_at changes on every write and the others are
+// associations that are audited as records of their own
+var ignoredFields = map[string]bool{"updated_at": true, "user": true, "sessions": true}
+
+// Diff compares the JSON representations of before and after, returning the fields whose
+// values differ. Either side may be nil, in which case every field of the other is returned.
+func Diff(before, after any) (map[string]models.AuditChange, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]models.AuditChange)
+	for name, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[name]) {
+			changes[name] = models.AuditChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, seen := beforeFields[name]; !seen && value != nil {
+			changes[name] = models.AuditChange{After: value}
+		}
+	}
+	for name := range ignoredFields {
+		delete(changes, name)
+	}
+
+	return changes, nil
+}
+
+// fields decodes a record's JSON into a map, so only fields exposed by the API are audited
+func fields(record any) (map[string]any, error) {
+	if record == nil {
+		return nil, nil
+	}
+	if v := reflect.ValueOf(record); v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
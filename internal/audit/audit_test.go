@@ -0,0 +1,187 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/audit"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("return only changed fields", func(t *testing.T) {
+		before := &models.Session{ID: 1, DurationSeconds: 600, SessionType: "breathing", Notes: "calm"}
+		after := &models.Session{ID: 1, DurationSeconds: 600, SessionType: "breathing", Notes: "restless"}
+
+		changes, err := audit.Diff(before, after)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]models.AuditChange{"notes": {Before: "calm", After: "restless"}}, changes)
+	})
+
+	t.Run("return every field when created", func(t *testing.T) {
+		changes, err := audit.Diff(nil, &models.Session{ID: 1, DurationSeconds: 600, SessionType: "breathing"})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.AuditChange{After: "breathing"}, changes["session_type"])
+		assert.NotContains(t, changes, "updated_at")
+		assert.NotContains(t, changes, "user")
+	})
+
+	t.Run("return nothing when deleting a nil record", func(t *testing.T) {
+		var token *models.AccessToken
+
+		changes, err := audit.Diff(token, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
+
+func TestActor(t *testing.T) {
+	t.Run("default to system without an actor", func(t *testing.T) {
+		actorType, actorID := audit.Actor(context.Background())
+
+		assert.Equal(t, audit.ActorSystem, actorType)
+		assert.Empty(t, actorID)
+	})
+
+	t.Run("replace the actor of an existing context", func(t *testing.T) {
+		ctx := audit.WithActor(context.Background(), audit.ActorWebhook, "")
+
+		audit.SetActor(ctx, audit.ActorAdmin, "admin_1")
+
+		actorType, actorID := audit.Actor(ctx)
+		assert.Equal(t, audit.ActorAdmin, actorType)
+		assert.Equal(t, "admin_1", actorID)
+	})
+}
+
+func TestWrap(t *testing.T) {
+	// setup returns audited repositories holding one user, a context acting as actorType and a
+	// function listing the user's recorded events
+	setup := func(actorType, actorID string) (context.Context, *models.User, *repository.Repositories, func() []models.AuditEvent) {
+		plain := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		require.NoError(t, plain.Users.Create(context.Background(), user))
+		ctx := audit.WithActor(context.Background(), actorType, actorID)
+
+		events := func() []models.AuditEvent {
+			events, err := plain.AuditEvents.ListForUser(ctx, user.ID, 0, 100)
+			require.NoError(t, err)
+
+			return events
+		}
+
+		return ctx, user, audit.Wrap(plain), events
+	}
+
+	t.Run("record session lifecycle", func(t *testing.T) {
+		ctx, user, repos, events := setup(audit.ActorAccessToken, "7")
+
+		session := testutils.CreateTestSession(user.ID)
+		require.NoError(t, repos.Sessions.Create(ctx, session))
+		require.NoError(t, repos.Sessions.Delete(ctx, session))
+		_, err := repos.Sessions.Restore(ctx, user.ID, session.ID)
+		require.NoError(t, err)
+
+		recorded := events()
+		require.Len(t, recorded, 3)
+		assert.Equal(t, "session.restored", recorded[0].Action)
+		assert.Equal(t, "session.deleted", recorded[1].Action)
+		assert.Equal(t, "session.created", recorded[2].Action)
+		assert.Equal(t, audit.ActorAccessToken, recorded[2].ActorType)
+		assert.Equal(t, "7", recorded[2].ActorID)
+		assert.Equal(t, "session", recorded[2].TargetType)
+		assert.Nil(t, recorded[1].Changes["session_type"].After)
+	})
+
+	t.Run("record profile changes with before and after", func(t *testing.T) {
+		ctx, user, repos, events := setup(audit.ActorWebhook, "3")
+
+		updated := *user
+		updated.Email = "new@example.com"
+		require.NoError(t, repos.Users.Update(ctx, &updated))
+
+		recorded := events()
+		require.Len(t, recorded, 1)
+		assert.Equal(t, "user.updated", recorded[0].Action)
+		assert.Equal(t, map[string]models.AuditChange{"email": {Before: user.Email, After: "new@example.com"}}, recorded[0].Changes)
+	})
+
+	t.Run("skip updates that change nothing", func(t *testing.T) {
+		ctx, user, repos, events := setup(audit.ActorWebhook, "3")
+
+		unchanged := *user
+		require.NoError(t, repos.Users.Update(ctx, &unchanged))
+
+		assert.Empty(t, events())
+	})
+
+	t.Run("record user deletion and restore", func(t *testing.T) {
+		ctx, user, repos, events := setup(audit.ActorAdmin, "admin_1")
+
+		require.NoError(t, repos.Users.DeleteByClerkUserID(ctx, user.ClerkUserID))
+		_, err := repos.Users.Restore(ctx, user.ID)
+		require.NoError(t, err)
+
+		recorded := events()
+		require.Len(t, recorded, 2)
+		assert.Equal(t, "user.restored", recorded[0].Action)
+		assert.Equal(t, "user.deleted", recorded[1].Action)
+	})
+
+	t.Run("record token creation and revocation without the hash", func(t *testing.T) {
+		ctx, user, repos, events := setup(audit.ActorUser, "user_123")
+
+		token := &models.AccessToken{UserID: user.ID, Name: "Shortcut", Prefix: "mmpat_abcdef", TokenHash: "secret_hash", Scopes: []string{"stats:read"}}
+		require.NoError(t, repos.AccessTokens.Create(ctx, token))
+		require.NoError(t, repos.AccessTokens.Delete(ctx, user.ID, token.ID))
+
+		recorded := events()
+		require.Len(t, recorded, 2)
+		assert.Equal(t, "access_token.revoked", recorded[0].Action)
+		assert.Equal(t, "Shortcut", recorded[0].Changes["name"].Before)
+		assert.Equal(t, "access_token.created", recorded[1].Action)
+		assert.NotContains(t, recorded[1].Changes, "token_hash")
+	})
+	t.Run("record preference changes against the defaults", func(t *testing.T) {
+		ctx, user, repos, events := setup(audit.ActorUser, "user_123")
+
+		preferences, err := repos.Preferences.Get(ctx, user.ID)
+		require.NoError(t, err)
+		preferences.Timezone = "Europe/Berlin"
+		require.NoError(t, repos.Preferences.Save(ctx, preferences))
+
+		recorded := events()
+		require.Len(t, recorded, 1)
+		assert.Equal(t, "preferences.updated", recorded[0].Action)
+		assert.Equal(t, map[string]models.AuditChange{"timezone": {Before: "UTC", After: "Europe/Berlin"}}, recorded[0].Changes)
+	})
+
+	t.Run("record reminder changes but not triggers", func(t *testing.T) {
+		ctx, user, repos, events := setup(audit.ActorUser, "user_123")
+
+		reminder := &models.Reminder{UserID: user.ID, Days: []string{"monday"}, LocalTime: "07:30", Enabled: true}
+		require.NoError(t, repos.Reminders.Create(ctx, reminder))
+		reminder.LocalTime = "08:00"
+		require.NoError(t, repos.Reminders.Update(ctx, reminder))
+		_, err := repos.Reminders.MarkTriggered(ctx, reminder.ID, time.Now(), time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repos.Reminders.Delete(ctx, user.ID, reminder.ID))
+
+		recorded := events()
+		require.Len(t, recorded, 3)
+		assert.Equal(t, "reminder.deleted", recorded[0].Action)
+		assert.Equal(t, "reminder.updated", recorded[1].Action)
+		assert.Equal(t, models.AuditChange{Before: "07:30", After: "08:00"}, recorded[1].Changes["local_time"])
+		assert.Equal(t, "reminder.created", recorded[2].Action)
+	})
+}
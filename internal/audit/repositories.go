@@ -0,0 +1,250 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+// Compile-time checks that every decorator satisfies its interface
+var (
+	_ repository.UserRepository        = (*UserRepository)(nil)
+	_ repository.SessionRepository     = (*SessionRepository)(nil)
+	_ repository.AccessTokenRepository = (*AccessTokenRepository)(nil)
+	_ repository.PreferencesRepository = (*PreferencesRepository)(nil)
+	_ repository.ReminderRepository    = (*ReminderRepository)(nil)
+)
+
+// Wrap returns a copy of repos whose user, session, access token, preference and reminder writes are audited to
+// repos.AuditEvents. Reads and the other repositories are passed through unchanged.
+func Wrap(repos *repository.Repositories) *repository.Repositories {
+	audited := *repos
+	audited.Users = &UserRepository{UserRepository: repos.Users, events: repos.AuditEvents}
+	audited.Sessions = &SessionRepository{SessionRepository: repos.Sessions, events: repos.AuditEvents}
+	audited.AccessTokens = &AccessTokenRepository{AccessTokenRepository: repos.AccessTokens, events: repos.AuditEvents}
+	audited.Preferences = &PreferencesRepository{PreferencesRepository: repos.Preferences, events: repos.AuditEvents}
+	audited.Reminders = &ReminderRepository{ReminderRepository: repos.Reminders, events: repos.AuditEvents}
+
+	return &audited
+}
+
+// UserRepository audits profile and role changes, deletes and restores. HardDelete isn't audited
+// because it removes the user's audit events along with everything else.
+type UserRepository struct {
+	repository.UserRepository
+
+	events repository.AuditEventRepository
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	if err := r.UserRepository.Create(ctx, user); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: user.ID, action: "user.created", targetType: "user", targetID: user.ID, after: user})
+
+	return nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	// A missing previous state only costs the diff its before values
+	before, _ := r.UserRepository.FindByID(ctx, user.ID)
+
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: user.ID, action: "user.updated", targetType: "user", targetID: user.ID, before: before, after: user})
+
+	return nil
+}
+
+func (r *UserRepository) DeleteByClerkUserID(ctx context.Context, clerkUserID string) error {
+	before, err := r.UserRepository.FindByClerkUserID(ctx, clerkUserID)
+	if err != nil {
+		return r.UserRepository.DeleteByClerkUserID(ctx, clerkUserID)
+	}
+
+	if err := r.UserRepository.DeleteByClerkUserID(ctx, clerkUserID); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: before.ID, action: "user.deleted", targetType: "user", targetID: before.ID, before: before})
+
+	return nil
+}
+
+func (r *UserRepository) Restore(ctx context.Context, id string) (*models.User, error) {
+	user, err := r.UserRepository.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	record(ctx, r.events, change{userID: user.ID, action: "user.restored", targetType: "user", targetID: user.ID, after: user})
+
+	return user, nil
+}
+
+func (r *UserRepository) SetDeletionRequestedAt(ctx context.Context, id string, at *time.Time) error {
+	before, err := r.UserRepository.FindByID(ctx, id)
+	if err != nil {
+		return r.UserRepository.SetDeletionRequestedAt(ctx, id, at)
+	}
+
+	if err := r.UserRepository.SetDeletionRequestedAt(ctx, id, at); err != nil {
+		return err
+	}
+
+	after := *before
+	after.DeletionRequestedAt = at
+	record(ctx, r.events, change{userID: id, action: "user.updated", targetType: "user", targetID: id, before: before, after: &after})
+
+	return nil
+}
+
+// SessionRepository audits session creation, deletion and restores
+type SessionRepository struct {
+	repository.SessionRepository
+
+	events repository.AuditEventRepository
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) error {
+	if err := r.SessionRepository.Create(ctx, session); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: session.UserID, action: "session.created", targetType: "session", targetID: formatID(session.ID), after: session})
+
+	return nil
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, session *models.Session) error {
+	before := *session
+
+	if err := r.SessionRepository.Delete(ctx, session); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: session.UserID, action: "session.deleted", targetType: "session", targetID: formatID(session.ID), before: &before})
+
+	return nil
+}
+
+func (r *SessionRepository) Restore(ctx context.Context, userID string, id uint) (*models.Session, error) {
+	session, err := r.SessionRepository.Restore(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	record(ctx, r.events, change{userID: userID, action: "session.restored", targetType: "session", targetID: formatID(id), after: session})
+
+	return session, nil
+}
+
+// AccessTokenRepository audits token creation and revocation. The token hash is never part of
+// the diff because it isn't serialised.
+type AccessTokenRepository struct {
+	repository.AccessTokenRepository
+
+	events repository.AuditEventRepository
+}
+
+func (r *AccessTokenRepository) Create(ctx context.Context, token *models.AccessToken) error {
+	if err := r.AccessTokenRepository.Create(ctx, token); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: token.UserID, action: "access_token.created", targetType: "access_token", targetID: formatID(token.ID), after: token})
+
+	return nil
+}
+
+func (r *AccessTokenRepository) Delete(ctx context.Context, userID string, id uint) error {
+	var before *models.AccessToken
+	if tokens, err := r.AccessTokenRepository.ListForUser(ctx, userID); err == nil {
+		for i := range tokens {
+			if tokens[i].ID == id {
+				before = &tokens[i]
+			}
+		}
+	}
+
+	if err := r.AccessTokenRepository.Delete(ctx, userID, id); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: userID, action: "access_token.revoked", targetType: "access_token", targetID: formatID(id), before: before})
+
+	return nil
+}
+
+// PreferencesRepository audits preference changes
+type PreferencesRepository struct {
+	repository.PreferencesRepository
+
+	events repository.AuditEventRepository
+}
+
+func (r *PreferencesRepository) Save(ctx context.Context, preferences *models.UserPreferences) error {
+	// A missing previous state only costs the diff its before values
+	before, _ := r.PreferencesRepository.Get(ctx, preferences.UserID)
+
+	if err := r.PreferencesRepository.Save(ctx, preferences); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: preferences.UserID, action: "preferences.updated", targetType: "preferences", targetID: preferences.UserID, before: before, after: preferences})
+
+	return nil
+}
+
+func formatID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// ReminderRepository audits reminder changes made by the user. The scheduler recording when a
+// reminder was triggered isn't audited.
+type ReminderRepository struct {
+	repository.ReminderRepository
+
+	events repository.AuditEventRepository
+}
+
+func (r *ReminderRepository) Create(ctx context.Context, reminder *models.Reminder) error {
+	if err := r.ReminderRepository.Create(ctx, reminder); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: reminder.UserID, action: "reminder.created", targetType: "reminder", targetID: formatID(reminder.ID), after: reminder})
+
+	return nil
+}
+
+func (r *ReminderRepository) Update(ctx context.Context, reminder *models.Reminder) error {
+	// A missing previous state only costs the diff its before values
+	before, _ := r.ReminderRepository.FindForUser(ctx, reminder.UserID, reminder.ID)
+
+	if err := r.ReminderRepository.Update(ctx, reminder); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: reminder.UserID, action: "reminder.updated", targetType: "reminder", targetID: formatID(reminder.ID), before: before, after: reminder})
+
+	return nil
+}
+
+func (r *ReminderRepository) Delete(ctx context.Context, userID string, id uint) error {
+	before, _ := r.ReminderRepository.FindForUser(ctx, userID, id)
+
+	if err := r.ReminderRepository.Delete(ctx, userID, id); err != nil {
+		return err
+	}
+
+	record(ctx, r.events, change{userID: userID, action: "reminder.deleted", targetType: "reminder", targetID: formatID(id), before: before})
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAccessToken(t *testing.T) {
+	t.Run("return prefixed token with matching hash and display prefix", func(t *testing.T) {
+		token, hash, prefix, err := auth.GenerateAccessToken()
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(token, auth.AccessTokenPrefix))
+		assert.True(t, strings.HasPrefix(token, prefix))
+		assert.Less(t, len(prefix), len(token))
+		assert.Equal(t, auth.HashAccessToken(token), hash)
+		assert.Len(t, hash, 64)
+	})
+
+	t.Run("return a different token each time", func(t *testing.T) {
+		first, _, _, _ := auth.GenerateAccessToken()
+		second, _, _, _ := auth.GenerateAccessToken()
+
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestAccessTokenAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+
+	// setup stores a token with the given scopes and routes /protected behind scope
+	setup := func(scopes []string, scope string) (*gin.Engine, *repository.Repositories, string) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_12345")
+		repos.Users.Create(context.Background(), user)
+
+		token, hash, prefix, _ := auth.GenerateAccessToken()
+		repos.AccessTokens.Create(context.Background(), &models.AccessToken{
+			UserID: user.ID, Name: "Raspberry Pi", Prefix: prefix, TokenHash: hash, Scopes: scopes,
+		})
+
+		router := gin.New()
+		router.GET("/protected", auth.AuthMiddleware(auth.NewClerkProvider(cfg), repos.Users, repos.AccessTokens), auth.RequireScope(scope), func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"user_id": auth.GetCurrentUserID(c)})
+		})
+
+		return router, repos, token
+	}
+	request := func(router *gin.Engine, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("return success when token has the route's scope", func(t *testing.T) {
+		router, repos, token := setup([]string{auth.ScopeSessionsWrite}, auth.ScopeSessionsWrite)
+
+		w := request(router, token)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		stored, _ := repos.AccessTokens.FindByHash(context.Background(), auth.HashAccessToken(token))
+		assert.NotNil(t, stored.LastUsedAt)
+	})
+
+	t.Run("return forbidden when token lacks the route's scope", func(t *testing.T) {
+		router, _, token := setup([]string{auth.ScopeStatsRead}, auth.ScopeSessionsWrite)
+
+		w := request(router, token)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "INSUFFICIENT_SCOPE")
+	})
+
+	t.Run("return forbidden when route only accepts session tokens", func(t *testing.T) {
+		router, _, token := setup(auth.Scopes, "")
+
+		w := request(router, token)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "cannot be used with a personal access token")
+	})
+
+	t.Run("return unauthorized when token is unknown or revoked", func(t *testing.T) {
+		router, repos, token := setup([]string{auth.ScopeStatsRead}, auth.ScopeStatsRead)
+		stored, _ := repos.AccessTokens.FindByHash(context.Background(), auth.HashAccessToken(token))
+		repos.AccessTokens.Delete(context.Background(), stored.UserID, stored.ID)
+
+		w := request(router, token)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_TOKEN")
+	})
+
+	t.Run("return unauthorized when token owner was deleted", func(t *testing.T) {
+		router, repos, token := setup([]string{auth.ScopeStatsRead}, auth.ScopeStatsRead)
+		repos.Users.DeleteByClerkUserID(context.Background(), "user_12345")
+
+		w := request(router, token)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "USER_NOT_FOUND")
+	})
+}
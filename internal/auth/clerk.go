@@ -1,17 +1,25 @@
 package auth
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/audit"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
-	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
 	"github.com/oklog/ulid/v2"
 )
 
@@ -29,6 +37,14 @@ type ClerkUser struct {
 	CreatedAt        int64                  `json:"created_at"`
 	UpdatedAt        int64                  `json:"updated_at"`
 	ExternalAccounts []ClerkExternalAccount `json:"external_accounts"`
+	PublicMetadata   ClerkPublicMetadata    `json:"public_metadata"`
+}
+
+// ClerkPublicMetadata is the part of a Clerk user's public metadata the API reads. Set
+// {"role": "admin"} in the Clerk dashboard to make a user an administrator; without it the
+// user has the user role.
+type ClerkPublicMetadata struct {
+	Role string `json:"role,omitempty"`
 }
 
 type ClerkEmailAddress struct {
@@ -40,11 +56,17 @@ type ClerkExternalAccount struct {
 	Provider string `json:"provider"`
 }
 
-func VerifyClerkWebhook(cfg *config.Config) gin.HandlerFunc {
+// WebhookResult describes the outcome of applying a webhook event
+type WebhookResult struct {
+	Message string
+	UserID  string
+}
+
+func VerifyClerkWebhook(cfg *config.Config, users repository.UserRepository, events repository.WebhookEventRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		secretKey := cfg.Auth.ClerkSecretKey
 		if secretKey == "" {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Clerk secret key not configured"})
+			apierror.Abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeWebhookNotConfigured, "Clerk secret key not configured"))
 
 			return
 		}
@@ -52,7 +74,7 @@ func VerifyClerkWebhook(cfg *config.Config) gin.HandlerFunc {
 		// Get the signature from headers
 		signature := c.GetHeader("svix-signature")
 		if signature == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Missing signature header"})
+			apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeMissingWebhookSignature, "Missing signature header"))
 
 			return
 		}
@@ -60,7 +82,7 @@ func VerifyClerkWebhook(cfg *config.Config) gin.HandlerFunc {
 		// Get the timestamp from headers
 		timestamp := c.GetHeader("svix-timestamp")
 		if timestamp == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Missing timestamp header"})
+			apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeMissingWebhookSignature, "Missing timestamp header"))
 
 			return
 		}
@@ -68,14 +90,16 @@ func VerifyClerkWebhook(cfg *config.Config) gin.HandlerFunc {
 		// Get the body
 		body, err := c.GetRawData()
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+			apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidWebhookPayload, "Failed to read request body"))
 
 			return
 		}
 
 		// Verify the signature
 		if !VerifySignature(body, signature, timestamp, secretKey) {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid signature"})
+			// The payload can't be trusted, so its type isn't used as a label
+			metrics.WebhookEvent("unknown", "rejected")
+			apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidWebhookSignature, "Invalid signature"))
 
 			return
 		}
@@ -83,22 +107,80 @@ func VerifyClerkWebhook(cfg *config.Config) gin.HandlerFunc {
 		// Parse the webhook event
 		var event ClerkWebhookEvent
 		if err := json.Unmarshal(body, &event); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+			metrics.WebhookEvent("unknown", "rejected")
+			apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidWebhookPayload, "Invalid JSON payload"))
 
 			return
 		}
 
-		// Handle different event types
-		switch event.Type {
-		case "user.created":
-			handleUserCreated(c, event.Data)
-		case "user.updated":
-			handleUserUpdated(c, event.Data)
-		case "user.deleted":
-			handleUserDeleted(c, event.Data)
-		default:
-			c.JSON(http.StatusOK, gin.H{"message": "Event type not handled"})
+		// Store the verified event so operators can inspect and replay it
+		stored := &models.WebhookEvent{
+			EventType:   event.Type,
+			ClerkUserID: event.Data.ID,
+			Payload:     string(body),
+			ReceivedAt:  time.Now(),
+		}
+		if err := events.Create(c.Request.Context(), stored); err != nil {
+			slog.ErrorContext(c.Request.Context(), "failed to store webhook event", slog.String("error", err.Error()))
+			stored = nil
+		}
+
+		// Changes are attributed to the stored delivery so the audit log leads back to its payload
+		webhookID := ""
+		if stored != nil {
+			webhookID = strconv.FormatUint(uint64(stored.ID), 10)
+		}
+		audit.SetActor(c.Request.Context(), audit.ActorWebhook, webhookID)
+
+		result, err := ApplyWebhookEvent(c.Request.Context(), users, event)
+
+		if stored != nil {
+			RecordWebhookOutcome(c.Request.Context(), events, stored, err)
+		}
+
+		if err != nil {
+			metrics.WebhookEvent(event.Type, "failed")
+			apierror.Abort(c, err)
+
+			return
 		}
+
+		metrics.WebhookEvent(event.Type, "processed")
+
+		response := gin.H{"message": result.Message}
+		if result.UserID != "" {
+			response["user_id"] = result.UserID
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// ApplyWebhookEvent applies a parsed Clerk event to the users repository.
+// It is shared by the webhook endpoint and by operators replaying stored events.
+func ApplyWebhookEvent(ctx context.Context, users repository.UserRepository, event ClerkWebhookEvent) (WebhookResult, error) {
+	switch event.Type {
+	case "user.created":
+		return handleUserCreated(ctx, users, event.Data)
+	case "user.updated":
+		return handleUserUpdated(ctx, users, event.Data)
+	case "user.deleted":
+		return handleUserDeleted(ctx, users, event.Data)
+	default:
+		return WebhookResult{Message: "Event type not handled"}, nil
+	}
+}
+
+// RecordWebhookOutcome marks a stored event as processed along with any error, logging rather than failing on storage errors
+func RecordWebhookOutcome(ctx context.Context, events repository.WebhookEventRepository, stored *models.WebhookEvent, processErr error) {
+	now := time.Now()
+	stored.ProcessedAt = &now
+	stored.Error = ""
+	if processErr != nil {
+		stored.Error = processErr.Error()
+	}
+
+	if err := events.Update(ctx, stored); err != nil {
+		slog.ErrorContext(ctx, "failed to record webhook event outcome", slog.Uint64("webhook_event_id", uint64(stored.ID)), slog.String("error", err.Error()))
 	}
 }
 
@@ -125,96 +207,82 @@ func VerifySignature(payload []byte, signature, timestamp, secret string) bool {
 	return false
 }
 
-func handleUserCreated(c *gin.Context, clerkUser ClerkUser) {
-	// Get primary email
-	var email string
-	for _, emailAddr := range clerkUser.EmailAddresses {
-		if emailAddr.Primary {
-			email = emailAddr.EmailAddress
-
-			break
-		}
-	}
-
-	if email == "" && len(clerkUser.EmailAddresses) > 0 {
-		email = clerkUser.EmailAddresses[0].EmailAddress
-	}
-
-	// Generate ULID
-	id := ulid.Make().String()
-
+func handleUserCreated(ctx context.Context, users repository.UserRepository, clerkUser ClerkUser) (WebhookResult, error) {
 	// Create user
 	user := models.User{
-		ID:          id,
+		ID:          ulid.Make().String(),
 		ClerkUserID: clerkUser.ID,
-		Email:       email,
+		Email:       primaryEmail(clerkUser),
 		FirstName:   clerkUser.FirstName,
 		LastName:    clerkUser.LastName,
+		Role:        clerkRole(clerkUser),
 	}
 
-	// Save to database
-	if err := database.DB.Create(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user", "details": err.Error()})
-
-		return
+	// Save to database. The user may already exist if it was provisioned on its first request
+	// before this event arrived, in which case the event's profile is applied to it.
+	err := users.Create(ctx, &user)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return handleUserUpdated(ctx, users, clerkUser)
+	}
+	if err != nil {
+		return WebhookResult{}, apierror.Internal("Failed to create user", err)
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "User created successfully",
-		"user_id": user.ID,
-	})
+	return WebhookResult{Message: "User created successfully", UserID: user.ID}, nil
 }
 
-func handleUserUpdated(c *gin.Context, clerkUser ClerkUser) {
+func handleUserUpdated(ctx context.Context, users repository.UserRepository, clerkUser ClerkUser) (WebhookResult, error) {
 	// Find existing user
-	var user models.User
-	if err := database.DB.Where("clerk_user_id = ?", clerkUser.ID).First(&user).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-
-		return
-	}
-
-	// Get primary email
-	var email string
-	for _, emailAddr := range clerkUser.EmailAddresses {
-		if emailAddr.Primary {
-			email = emailAddr.EmailAddress
-
-			break
-		}
-	}
-
-	if email == "" && len(clerkUser.EmailAddresses) > 0 {
-		email = clerkUser.EmailAddresses[0].EmailAddress
+	user, err := users.FindByClerkUserID(ctx, clerkUser.ID)
+	if err != nil {
+		return WebhookResult{}, apierror.New(http.StatusNotFound, apierror.CodeUserNotFound, "User not found").WithCause(err)
 	}
 
 	// Update user
-	user.Email = email
+	user.Email = primaryEmail(clerkUser)
 	user.FirstName = clerkUser.FirstName
 	user.LastName = clerkUser.LastName
+	// Clerk is the source of truth for roles, so removing the role from the metadata demotes an admin
+	user.Role = clerkRole(clerkUser)
 
 	// Save to database
-	if err := database.DB.Save(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
+	if err := users.Update(ctx, user); err != nil {
+		return WebhookResult{}, apierror.Internal("Failed to update user", err)
+	}
+
+	return WebhookResult{Message: "User updated successfully", UserID: user.ID}, nil
+}
+
+func handleUserDeleted(ctx context.Context, users repository.UserRepository, clerkUser ClerkUser) (WebhookResult, error) {
+	// Soft delete the user. It can be restored by an admin until the account purge removes it
+	// with all of its data once the deletion grace period has passed.
+	if err := users.DeleteByClerkUserID(ctx, clerkUser.ID); err != nil {
+		return WebhookResult{}, apierror.Internal("Failed to delete user", err)
+	}
+
+	return WebhookResult{Message: "User deleted successfully"}, nil
+}
 
-		return
+// clerkRole returns the role set in the user's public metadata, or RoleUser if it has no valid one
+func clerkRole(clerkUser ClerkUser) string {
+	if models.IsValidRole(clerkUser.PublicMetadata.Role) {
+		return clerkUser.PublicMetadata.Role
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "User updated successfully",
-		"user_id": user.ID,
-	})
+	return models.RoleUser
 }
 
-func handleUserDeleted(c *gin.Context, clerkUser ClerkUser) {
-	// Soft delete user
-	if err := database.DB.Where("clerk_user_id = ?", clerkUser.ID).Delete(&models.User{}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete user"})
+// primaryEmail returns the user's primary email, falling back to the first address listed
+func primaryEmail(clerkUser ClerkUser) string {
+	for _, emailAddr := range clerkUser.EmailAddresses {
+		if emailAddr.Primary {
+			return emailAddr.EmailAddress
+		}
+	}
 
-		return
+	if len(clerkUser.EmailAddresses) > 0 {
+		return clerkUser.EmailAddresses[0].EmailAddress
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "User deleted successfully",
-	})
+	return ""
 }
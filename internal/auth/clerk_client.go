@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ErrClerkUserNotFound is returned when Clerk has no user with the requested ID
+var ErrClerkUserNotFound = errors.New("clerk user not found")
+
+// ClerkClient calls the Clerk Backend API. The base URL is configurable so tests and local
+// development can point it at a stub.
+type ClerkClient struct {
+	baseURL   string
+	secretKey string
+	http      *http.Client
+}
+
+// NewClerkClient returns a client for the Backend API at baseURL, e.g. https://api.clerk.com/v1
+func NewClerkClient(baseURL, secretKey string) *ClerkClient {
+	return &ClerkClient{
+		baseURL:   baseURL,
+		secretKey: secretKey,
+		http:      &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
+	}
+}
+
+// GetUser fetches a user by Clerk user ID. The response has the same shape as webhook event data.
+func (c *ClerkClient) GetUser(ctx context.Context, clerkUserID string) (ClerkUser, error) {
+	ctx, span := tracing.Start(ctx, "auth.ClerkClient.GetUser")
+	defer span.End()
+
+	clerkUser, err := c.getUser(ctx, clerkUserID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return clerkUser, err
+}
+
+func (c *ClerkClient) getUser(ctx context.Context, clerkUserID string) (ClerkUser, error) {
+	if c.secretKey == "" {
+		return ClerkUser{}, fmt.Errorf("clerk secret key not configured")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/users/"+url.PathEscape(clerkUserID), nil)
+	if err != nil {
+		return ClerkUser{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.secretKey)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return ClerkUser{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ClerkUser{}, ErrClerkUserNotFound
+	case resp.StatusCode != http.StatusOK:
+		return ClerkUser{}, fmt.Errorf("clerk api returned status %d", resp.StatusCode)
+	}
+
+	var clerkUser ClerkUser
+	if err := json.NewDecoder(resp.Body).Decode(&clerkUser); err != nil {
+		return ClerkUser{}, err
+	}
+
+	return clerkUser, nil
+}
@@ -0,0 +1,69 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClerkClient(t *testing.T) {
+	// A local stub of the Backend API's GET /users/{id}
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test_secret_key" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/users/user_123":
+			_, _ = w.Write([]byte(`{"id": "user_123", "first_name": "Ada", "email_addresses": [{"email_address": "ada@example.com", "primary": true}]}`))
+		case "/v1/users/user_broken":
+			_, _ = w.Write([]byte(`not json`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer stub.Close()
+
+	client := auth.NewClerkClient(stub.URL+"/v1", "test_secret_key")
+
+	t.Run("return user when it exists", func(t *testing.T) {
+		user, err := client.GetUser(context.Background(), "user_123")
+
+		require.NoError(t, err)
+		assert.Equal(t, "user_123", user.ID)
+		assert.Equal(t, "Ada", *user.FirstName)
+		assert.Equal(t, "ada@example.com", user.EmailAddresses[0].EmailAddress)
+	})
+
+	t.Run("return not found error when user does not exist", func(t *testing.T) {
+		_, err := client.GetUser(context.Background(), "user_missing")
+
+		assert.ErrorIs(t, err, auth.ErrClerkUserNotFound)
+	})
+
+	t.Run("return error when secret key is rejected", func(t *testing.T) {
+		_, err := auth.NewClerkClient(stub.URL+"/v1", "wrong_key").GetUser(context.Background(), "user_123")
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, auth.ErrClerkUserNotFound)
+	})
+
+	t.Run("return error when secret key is missing", func(t *testing.T) {
+		_, err := auth.NewClerkClient(stub.URL+"/v1", "").GetUser(context.Background(), "user_123")
+
+		assert.ErrorContains(t, err, "clerk secret key not configured")
+	})
+
+	t.Run("return error when response JSON is invalid", func(t *testing.T) {
+		_, err := client.GetUser(context.Background(), "user_broken")
+
+		assert.Error(t, err)
+	})
+}
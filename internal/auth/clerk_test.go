@@ -2,6 +2,7 @@ package auth_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -9,10 +10,12 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
-	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
@@ -21,9 +24,7 @@ import (
 func TestVerifyClerkWebhook(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
-	db := testutils.SetupTestDB(t)
-	database.DB = db
-	defer testutils.CleanupTestDB(t, db)
+	repos := memory.NewRepositories()
 
 	// Create test config
 	cfg := &config.Config{
@@ -32,17 +33,17 @@ func TestVerifyClerkWebhook(t *testing.T) {
 		},
 	}
 
-	router := gin.New()
-	router.POST("/webhooks/clerk", auth.VerifyClerkWebhook(cfg))
+	var router *gin.Engine
 
-	// Helper function to clean database before each test
-	cleanDB := func() {
-		testutils.TruncateTable(db, "users")
-		testutils.TruncateTable(db, "sessions")
+	// Helper function to reset repositories and the router wired to them before each test
+	resetRepos := func() {
+		repos = memory.NewRepositories()
+		router = gin.New()
+		router.POST("/webhooks/clerk", auth.VerifyClerkWebhook(cfg, repos.Users, repos.WebhookEvents))
 	}
 
 	t.Run("return internal server error when secret key is missing", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		// Create config with empty secret key
 		emptyCfg := &config.Config{
@@ -52,7 +53,7 @@ func TestVerifyClerkWebhook(t *testing.T) {
 		}
 
 		emptyRouter := gin.New()
-		emptyRouter.POST("/webhooks/clerk", auth.VerifyClerkWebhook(emptyCfg))
+		emptyRouter.POST("/webhooks/clerk", auth.VerifyClerkWebhook(emptyCfg, repos.Users, repos.WebhookEvents))
 
 		req := httptest.NewRequest("POST", "/webhooks/clerk", bytes.NewBuffer([]byte("{}")))
 		w := httptest.NewRecorder()
@@ -64,7 +65,7 @@ func TestVerifyClerkWebhook(t *testing.T) {
 	})
 
 	t.Run("return bad request when signature header is missing", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		req := httptest.NewRequest("POST", "/webhooks/clerk", bytes.NewBuffer([]byte("{}")))
 		w := httptest.NewRecorder()
@@ -76,7 +77,7 @@ func TestVerifyClerkWebhook(t *testing.T) {
 	})
 
 	t.Run("return bad request when timestamp header is missing", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		req := httptest.NewRequest("POST", "/webhooks/clerk", bytes.NewBuffer([]byte("{}")))
 		req.Header.Set("svix-signature", "v1,test_signature")
@@ -89,7 +90,7 @@ func TestVerifyClerkWebhook(t *testing.T) {
 	})
 
 	t.Run("return unauthorized when signature is invalid", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		payload := `{"type": "user.created", "data": {"id": "test_user"}}`
 		timestamp := "1234567890"
@@ -106,7 +107,7 @@ func TestVerifyClerkWebhook(t *testing.T) {
 	})
 
 	t.Run("return bad request when JSON payload is invalid", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		payload := `{invalid json}`
 		timestamp := "1234567890"
@@ -124,7 +125,7 @@ func TestVerifyClerkWebhook(t *testing.T) {
 	})
 
 	t.Run("successfully create user when user.created event is received", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		event := auth.ClerkWebhookEvent{
 			Type: "user.created",
@@ -155,16 +156,25 @@ func TestVerifyClerkWebhook(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "User created successfully")
 
 		// Verify user was created in database
-		var user models.User
-		err := db.Where("clerk_user_id = ?", "test_user_123").First(&user).Error
+		user, err := repos.Users.FindByClerkUserID(context.Background(), "test_user_123")
 		assert.NoError(t, err)
 		assert.Equal(t, "test@example.com", user.Email)
 		assert.Equal(t, "John", *user.FirstName)
 		assert.Equal(t, "Doe", *user.LastName)
+
+		// Verify the event was stored as processed
+		events, err := repos.WebhookEvents.List(context.Background(), 10)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, "user.created", events[0].EventType)
+		assert.Equal(t, "test_user_123", events[0].ClerkUserID)
+		assert.JSONEq(t, string(payload), events[0].Payload)
+		assert.NotNil(t, events[0].ProcessedAt)
+		assert.Empty(t, events[0].Error)
 	})
 
 	t.Run("successfully create user with empty email when no email addresses provided", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		event := auth.ClerkWebhookEvent{
 			Type: "user.created",
@@ -192,18 +202,17 @@ func TestVerifyClerkWebhook(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 
 		// Verify user was created with empty email
-		var user models.User
-		err := db.Where("clerk_user_id = ?", "test_user_123").First(&user).Error
+		user, err := repos.Users.FindByClerkUserID(context.Background(), "test_user_123")
 		assert.NoError(t, err)
 		assert.Equal(t, "", user.Email)
 	})
 
 	t.Run("successfully update existing user when user.updated event is received", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		// Create existing user
 		existingUser := testutils.CreateTestUser("test_user_123")
-		db.Create(existingUser)
+		repos.Users.Create(context.Background(), existingUser)
 
 		event := auth.ClerkWebhookEvent{
 			Type: "user.updated",
@@ -234,8 +243,7 @@ func TestVerifyClerkWebhook(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "User updated successfully")
 
 		// Verify user was updated in database
-		var user models.User
-		err := db.Where("clerk_user_id = ?", "test_user_123").First(&user).Error
+		user, err := repos.Users.FindByClerkUserID(context.Background(), "test_user_123")
 		assert.NoError(t, err)
 		assert.Equal(t, "updated@example.com", user.Email)
 		assert.Equal(t, "Jane", *user.FirstName)
@@ -243,7 +251,7 @@ func TestVerifyClerkWebhook(t *testing.T) {
 	})
 
 	t.Run("return not found when updating non-existent user", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		event := auth.ClerkWebhookEvent{
 			Type: "user.updated",
@@ -272,14 +280,20 @@ func TestVerifyClerkWebhook(t *testing.T) {
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 		assert.Contains(t, w.Body.String(), "User not found")
+
+		// Verify the failure was recorded on the stored event
+		events, err := repos.WebhookEvents.List(context.Background(), 10)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Contains(t, events[0].Error, "User not found")
 	})
 
 	t.Run("successfully soft delete user when user.deleted event is received", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		// Create existing user
 		existingUser := testutils.CreateTestUser("test_user_123")
-		db.Create(existingUser)
+		repos.Users.Create(context.Background(), existingUser)
 
 		event := auth.ClerkWebhookEvent{
 			Type: "user.deleted",
@@ -303,14 +317,12 @@ func TestVerifyClerkWebhook(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "User deleted successfully")
 
 		// Verify user was soft deleted
-		var user models.User
-		err := db.Unscoped().Where("clerk_user_id = ?", "test_user_123").First(&user).Error
-		assert.NoError(t, err)
-		assert.NotNil(t, user.DeletedAt)
+		_, err := repos.Users.FindByClerkUserID(context.Background(), "test_user_123")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
 	})
 
 	t.Run("return ok with message when unhandled event type is received", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		event := auth.ClerkWebhookEvent{
 			Type: "user.unknown",
@@ -333,6 +345,117 @@ func TestVerifyClerkWebhook(t *testing.T) {
 	})
 }
 
+func TestApplyWebhookEvent(t *testing.T) {
+	t.Run("successfully replay user.created event", func(t *testing.T) {
+		repos := memory.NewRepositories()
+
+		event := auth.ClerkWebhookEvent{
+			Type: "user.created",
+			Data: auth.ClerkUser{
+				ID:             "test_user_123",
+				EmailAddresses: []auth.ClerkEmailAddress{{EmailAddress: "test@example.com"}},
+			},
+		}
+
+		result, err := auth.ApplyWebhookEvent(context.Background(), repos.Users, event)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "User created successfully", result.Message)
+
+		user, err := repos.Users.FindByClerkUserID(context.Background(), "test_user_123")
+		assert.NoError(t, err)
+		assert.Equal(t, result.UserID, user.ID)
+		assert.Equal(t, "test@example.com", user.Email)
+	})
+
+	t.Run("enrich user provisioned before user.created event arrived", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		provisioned := &models.User{ClerkUserID: "test_user_123"}
+		assert.NoError(t, repos.Users.Create(context.Background(), provisioned))
+
+		event := auth.ClerkWebhookEvent{
+			Type: "user.created",
+			Data: auth.ClerkUser{
+				ID:             "test_user_123",
+				EmailAddresses: []auth.ClerkEmailAddress{{EmailAddress: "test@example.com"}},
+				FirstName:      lo.ToPtr("John"),
+			},
+		}
+
+		result, err := auth.ApplyWebhookEvent(context.Background(), repos.Users, event)
+
+		assert.NoError(t, err)
+		assert.Equal(t, provisioned.ID, result.UserID)
+
+		user, err := repos.Users.FindByClerkUserID(context.Background(), "test_user_123")
+		assert.NoError(t, err)
+		assert.Equal(t, "test@example.com", user.Email)
+		assert.Equal(t, "John", *user.FirstName)
+	})
+
+	t.Run("sync role from clerk public metadata", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		event := auth.ClerkWebhookEvent{
+			Type: "user.created",
+			Data: auth.ClerkUser{ID: "test_admin", PublicMetadata: auth.ClerkPublicMetadata{Role: models.RoleAdmin}},
+		}
+
+		_, err := auth.ApplyWebhookEvent(context.Background(), repos.Users, event)
+		assert.NoError(t, err)
+
+		user, err := repos.Users.FindByClerkUserID(context.Background(), "test_admin")
+		assert.NoError(t, err)
+		assert.Equal(t, models.RoleAdmin, user.Role)
+	})
+
+	t.Run("demote admin when clerk metadata has no role", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		admin := testutils.CreateTestUser("test_admin")
+		admin.Role = models.RoleAdmin
+		assert.NoError(t, repos.Users.Create(context.Background(), admin))
+
+		event := auth.ClerkWebhookEvent{Type: "user.updated", Data: auth.ClerkUser{ID: "test_admin"}}
+		_, err := auth.ApplyWebhookEvent(context.Background(), repos.Users, event)
+		assert.NoError(t, err)
+
+		user, err := repos.Users.FindByClerkUserID(context.Background(), "test_admin")
+		assert.NoError(t, err)
+		assert.Equal(t, models.RoleUser, user.Role)
+	})
+
+	t.Run("ignore unknown roles from clerk metadata", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		event := auth.ClerkWebhookEvent{
+			Type: "user.created",
+			Data: auth.ClerkUser{ID: "test_user", PublicMetadata: auth.ClerkPublicMetadata{Role: "superuser"}},
+		}
+
+		_, err := auth.ApplyWebhookEvent(context.Background(), repos.Users, event)
+		assert.NoError(t, err)
+
+		user, err := repos.Users.FindByClerkUserID(context.Background(), "test_user")
+		assert.NoError(t, err)
+		assert.Equal(t, models.RoleUser, user.Role)
+	})
+
+	t.Run("return api error with not found status when updating non-existent user", func(t *testing.T) {
+		repos := memory.NewRepositories()
+
+		event := auth.ClerkWebhookEvent{
+			Type: "user.updated",
+			Data: auth.ClerkUser{ID: "nonexistent_user"},
+		}
+
+		_, err := auth.ApplyWebhookEvent(context.Background(), repos.Users, event)
+
+		var apiErr *apierror.Error
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.Status)
+		assert.Equal(t, apierror.CodeUserNotFound, apiErr.Code)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
 func TestVerifySignature(t *testing.T) {
 	secret := "test_secret_key"
 
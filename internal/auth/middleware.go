@@ -1,15 +1,27 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/audit"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
-	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/logging"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type ClerkJWTClaims struct {
@@ -20,13 +32,14 @@ type ClerkJWTClaims struct {
 	Azp string `json:"azp"`
 }
 
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+// AuthMiddleware authenticates requests with either a session token verified by the auth provider
+// or a personal access token, which is recognised by its AccessTokenPrefix
+func AuthMiddleware(provider Provider, users repository.UserRepository, tokens repository.AccessTokenRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing authorization header"})
-			c.Abort()
+			apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeMissingAuthorization, "Missing authorization header"))
 
 			return
 		}
@@ -34,42 +47,96 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 		// Extract token from "Bearer <token>" format
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
-			c.Abort()
+			apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidAuthorization, "Invalid authorization header format"))
 
 			return
 		}
 
 		token := parts[1]
 
-		// Verify token with Clerk
-		clerkUserID, err := VerifyClerkToken(token, cfg)
+		if strings.HasPrefix(token, AccessTokenPrefix) {
+			user, accessToken, apiErr := authenticateAccessToken(c.Request.Context(), token, tokens, users)
+			if apiErr != nil {
+				apierror.Abort(c, apiErr)
+
+				return
+			}
+
+			logging.SetUserID(c.Request.Context(), user.ID)
+			audit.SetActor(c.Request.Context(), audit.ActorAccessToken, strconv.FormatUint(uint64(accessToken.ID), 10))
+			c.Set("user", *user)
+			c.Set("user_id", user.ID)
+			c.Set("access_token", *accessToken)
+
+			c.Next()
+
+			return
+		}
+
+		// Verify token with the auth provider
+		identity, err := provider.Verify(c.Request.Context(), token)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
+			apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid token"))
 
 			return
 		}
 
-		// Get user from database
-		var user models.User
-		if err := database.DB.Where("clerk_user_id = ?", clerkUserID).First(&user).Error; err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
-			c.Abort()
+		// Get user from database, letting the provider create it on first sign-in
+		user, err := users.FindByClerkUserID(c.Request.Context(), identity.Subject)
+		if errors.Is(err, repository.ErrNotFound) {
+			audit.SetActor(c.Request.Context(), audit.ActorAuthProvider, provider.Name())
+			user, err = provider.Provision(c.Request.Context(), users, identity)
+		}
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, ErrUserNotProvisioned) {
+			apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+			return
+		}
+		if err != nil {
+			apierror.Abort(c, apierror.Internal("Failed to load user", err))
 
 			return
 		}
 
 		// Set user in context
-		c.Set("user", user)
+		logging.SetUserID(c.Request.Context(), user.ID)
+		audit.SetActor(c.Request.Context(), audit.ActorUser, user.ID)
+		c.Set("user", *user)
 		c.Set("user_id", user.ID)
-		c.Set("clerk_user_id", clerkUserID)
+		c.Set("clerk_user_id", identity.Subject)
 
 		c.Next()
 	}
 }
 
-func VerifyClerkToken(token string, cfg *config.Config) (string, error) {
+// errTokenRejected is returned when Clerk answers but does not accept the token
+var errTokenRejected = errors.New("token verification failed")
+
+// VerifyClerkToken resolves a session token to a Clerk user ID, recording latency and outcome
+func VerifyClerkToken(ctx context.Context, token string, cfg *config.Config) (string, error) {
+	ctx, span := tracing.Start(ctx, "auth.VerifyClerkToken")
+	defer span.End()
+
+	start := time.Now()
+	clerkUserID, err := verifyClerkToken(ctx, token, cfg)
+
+	outcome := "success"
+	switch {
+	case errors.Is(err, errTokenRejected):
+		outcome = "rejected"
+	case err != nil:
+		outcome = "error"
+	}
+	metrics.ObserveClerkVerification(outcome, time.Since(start))
+	span.SetAttributes(attribute.String("auth.outcome", outcome))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return clerkUserID, err
+}
+
+func verifyClerkToken(ctx context.Context, token string, cfg *config.Config) (string, error) {
 	// In a real implementation, you would verify the JWT token against Clerk's JWKS endpoint
 	// For now, we'll implement a simple verification mechanism
 
@@ -83,8 +150,8 @@ func VerifyClerkToken(token string, cfg *config.Config) (string, error) {
 	// In production, you should use proper JWT library like golang-jwt/jwt
 
 	// Make HTTP request to Clerk's verification endpoint
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", cfg.Auth.ClerkVerifyURL, nil)
+	client := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	req, err := http.NewRequestWithContext(ctx, "GET", cfg.Auth.ClerkVerifyURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -104,7 +171,7 @@ func VerifyClerkToken(token string, cfg *config.Config) (string, error) {
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("token verification failed")
+		return "", errTokenRejected
 	}
 
 	// Parse response to get user ID
@@ -119,6 +186,20 @@ func VerifyClerkToken(token string, cfg *config.Config) (string, error) {
 	return response.Sub, nil
 }
 
+// RequireRole rejects requests from users who don't hold role. It runs after AuthMiddleware.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := GetCurrentUser(c)
+		if user == nil || user.Role != role {
+			apierror.Abort(c, apierror.New(http.StatusForbidden, apierror.CodeInsufficientRole, "This route requires the "+role+" role"))
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
 func GetCurrentUser(c *gin.Context) *models.User {
 	if user, exists := c.Get("user"); exists {
 		if u, ok := user.(models.User); ok {
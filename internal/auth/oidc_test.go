@@ -0,0 +1,177 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIssuer is an OIDC issuer serving discovery and JWKS documents for a single signing key
+type testIssuer struct {
+	server *httptest.Server
+	signer jose.Signer
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+	require.NoError(t, err)
+
+	issuer := &testIssuer{signer: signer}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                issuer.server.URL,
+			"jwks_uri":                              issuer.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+// token signs claims for subject, filling in the registered claims a valid token needs
+func (i *testIssuer) token(t *testing.T, subject, audience string, expiry time.Time, extra map[string]any) string {
+	t.Helper()
+
+	token, err := jwt.Signed(i.signer).
+		Claims(jwt.Claims{Issuer: i.server.URL, Subject: subject, Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(expiry)}).
+		Claims(extra).
+		Serialize()
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestOIDCProvider(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider, err := auth.NewOIDCProvider(context.Background(), config.OIDCConfig{IssuerURL: issuer.server.URL, Audience: "mindful-minutes"})
+	require.NoError(t, err)
+
+	t.Run("return identity from claims when token is valid", func(t *testing.T) {
+		token := issuer.token(t, "oidc|123", "mindful-minutes", time.Now().Add(time.Hour), map[string]any{
+			"email": "ada@example.com", "given_name": "Ada",
+		})
+
+		identity, err := provider.Verify(context.Background(), token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "oidc|123", identity.Subject)
+		assert.Equal(t, "ada@example.com", identity.Email)
+		require.NotNil(t, identity.FirstName)
+		assert.Equal(t, "Ada", *identity.FirstName)
+		assert.Nil(t, identity.LastName)
+	})
+
+	t.Run("return error when token is for another audience", func(t *testing.T) {
+		token := issuer.token(t, "oidc|123", "someone-else", time.Now().Add(time.Hour), nil)
+
+		_, err := provider.Verify(context.Background(), token)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("return error when token is expired", func(t *testing.T) {
+		token := issuer.token(t, "oidc|123", "mindful-minutes", time.Now().Add(-time.Hour), nil)
+
+		_, err := provider.Verify(context.Background(), token)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("return error when token is signed by another key", func(t *testing.T) {
+		other := newTestIssuer(t)
+		token := other.token(t, "oidc|123", "mindful-minutes", time.Now().Add(time.Hour), nil)
+
+		_, err := provider.Verify(context.Background(), token)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("return no webhook handler", func(t *testing.T) {
+		assert.Nil(t, provider.WebhookHandler(nil, nil))
+	})
+
+	t.Run("return error when issuer cannot be discovered", func(t *testing.T) {
+		_, err := auth.NewOIDCProvider(context.Background(), config.OIDCConfig{IssuerURL: issuer.server.URL + "/missing", Audience: "mindful-minutes"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthMiddlewareWithOIDC(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	issuer := newTestIssuer(t)
+	provider, err := auth.NewOIDCProvider(context.Background(), config.OIDCConfig{IssuerURL: issuer.server.URL, Audience: "mindful-minutes"})
+	require.NoError(t, err)
+
+	repos := memory.NewRepositories()
+	router := gin.New()
+	router.GET("/protected", auth.AuthMiddleware(provider, repos.Users, repos.AccessTokens), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": auth.GetCurrentUserID(c)})
+	})
+
+	request := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("provision user on first request", func(t *testing.T) {
+		token := issuer.token(t, "oidc|new", "mindful-minutes", time.Now().Add(time.Hour), map[string]any{"email": "new@example.com"})
+
+		w := request(token)
+
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		user, err := repos.Users.FindByClerkUserID(context.Background(), "oidc|new")
+		require.NoError(t, err)
+		assert.Equal(t, "new@example.com", user.Email)
+		assert.Contains(t, w.Body.String(), user.ID)
+	})
+
+	t.Run("reuse existing user", func(t *testing.T) {
+		existing := testutils.CreateTestUser("oidc|existing")
+		require.NoError(t, repos.Users.Create(context.Background(), existing))
+		token := issuer.token(t, "oidc|existing", "mindful-minutes", time.Now().Add(time.Hour), nil)
+
+		w := request(token)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), existing.ID)
+	})
+
+	t.Run("return unauthorized when token is invalid", func(t *testing.T) {
+		w := request("not-a-jwt")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid token")
+	})
+}
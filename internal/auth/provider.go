@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+// ErrUserNotProvisioned is returned by Provider.Provision when the provider doesn't know the user
+var ErrUserNotProvisioned = errors.New("user is not provisioned by this provider")
+
+// Identity is what a provider verified about the caller. Subject is the provider's stable user ID,
+// stored as the user's ClerkUserID whichever provider issued it.
+type Identity struct {
+	Subject   string
+	Email     string
+	FirstName *string
+	LastName  *string
+	// Role is the role the provider assigns, if any
+	Role string
+}
+
+// Provider verifies user tokens and provisions the local users they belong to
+type Provider interface {
+	// Name identifies the provider, e.g. in its webhook path
+	Name() string
+	// Verify checks a bearer token and returns who it was issued to
+	Verify(ctx context.Context, token string) (Identity, error)
+	// Provision creates the local user for an identity that has none yet, or returns ErrUserNotProvisioned.
+	// It must tolerate concurrent calls for the same identity.
+	Provision(ctx context.Context, users repository.UserRepository, identity Identity) (*models.User, error)
+	// WebhookHandler returns the endpoint the provider pushes user changes to, or nil if it has none
+	WebhookHandler(users repository.UserRepository, events repository.WebhookEventRepository) gin.HandlerFunc
+}
+
+// NewProvider builds the provider selected by cfg.Auth.Provider. ctx bounds the OIDC discovery
+// request made on startup.
+func NewProvider(ctx context.Context, cfg *config.Config) (Provider, error) {
+	switch cfg.Auth.Provider {
+	case "oidc":
+		return NewOIDCProvider(ctx, cfg.Auth.OIDC)
+	case "clerk", "":
+		return NewClerkProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown auth provider %q", cfg.Auth.Provider)
+	}
+}
+
+// ClerkProvider verifies Clerk session tokens. Users are normally created by Clerk's webhooks;
+// if a user.created event was lost, the user is fetched from the Backend API on first sign-in.
+type ClerkProvider struct {
+	cfg    *config.Config
+	client *ClerkClient
+}
+
+// NewClerkProvider returns a provider using the Clerk settings in cfg
+func NewClerkProvider(cfg *config.Config) *ClerkProvider {
+	return &ClerkProvider{cfg: cfg, client: NewClerkClient(cfg.Auth.ClerkAPIURL, cfg.Auth.ClerkSecretKey)}
+}
+
+func (p *ClerkProvider) Name() string {
+	return "clerk"
+}
+
+func (p *ClerkProvider) Verify(ctx context.Context, token string) (Identity, error) {
+	clerkUserID, err := VerifyClerkToken(ctx, token, p.cfg)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	return Identity{Subject: clerkUserID}, nil
+}
+
+// Provision creates a user the webhook hasn't delivered yet from its Clerk profile. The webhook
+// fills in any later changes when it arrives.
+func (p *ClerkProvider) Provision(ctx context.Context, users repository.UserRepository, identity Identity) (*models.User, error) {
+	clerkUser, err := p.client.GetUser(ctx, identity.Subject)
+	if errors.Is(err, ErrClerkUserNotFound) {
+		return nil, ErrUserNotProvisioned
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch clerk user: %w", err)
+	}
+
+	return provisionUser(ctx, p.Name(), users, Identity{
+		Subject:   identity.Subject,
+		Email:     primaryEmail(clerkUser),
+		FirstName: clerkUser.FirstName,
+		LastName:  clerkUser.LastName,
+		Role:      clerkRole(clerkUser),
+	})
+}
+
+func (p *ClerkProvider) WebhookHandler(users repository.UserRepository, events repository.WebhookEventRepository) gin.HandlerFunc {
+	return VerifyClerkWebhook(p.cfg, users, events)
+}
+
+// provisionUser creates a user from a verified identity. If a concurrent request or the webhook
+// created the same user first, the unique constraint on the subject rejects this one and the
+// existing user is returned.
+func provisionUser(ctx context.Context, provider string, users repository.UserRepository, identity Identity) (*models.User, error) {
+	user := &models.User{
+		ClerkUserID: identity.Subject,
+		Email:       identity.Email,
+		FirstName:   identity.FirstName,
+		LastName:    identity.LastName,
+		Role:        models.RoleUser,
+	}
+	if models.IsValidRole(identity.Role) {
+		user.Role = identity.Role
+	}
+
+	err := users.Create(ctx, user)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return users.FindByClerkUserID(ctx, identity.Subject)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.UserProvisioned(provider)
+	slog.InfoContext(ctx, "provisioned user on first request", slog.String("provider", provider), slog.String("user_id", user.ID))
+
+	return user, nil
+}
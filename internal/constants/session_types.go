@@ -8,4 +8,14 @@ const (
 	SessionTypeBodyScan    = "body_scan"
 	SessionTypeWalking     = "walking"
 	SessionTypeOther       = "other"
-)
\ No newline at end of file
+)
+
+// SessionTypes lists every valid session type
+var SessionTypes = []string{
+	SessionTypeMindfulness,
+	SessionTypeBreathing,
+	SessionTypeMetta,
+	SessionTypeBodyScan,
+	SessionTypeWalking,
+	SessionTypeOther,
+}
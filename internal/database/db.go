@@ -2,36 +2,50 @@ package database
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-var DB *gorm.DB
+// NowUTC is GORM's clock for created_at, updated_at and deleted_at. Timestamp columns don't keep
+// a zone and queries read them as UTC, so they must not follow the host's local time.
+func NowUTC() time.Time {
+	return time.Now().UTC()
+}
 
-func Connect(databaseURL string) error {
+// Connect opens a Postgres connection that logs through the given GORM logger
+func Connect(databaseURL string, gormLogger logger.Interface) (*gorm.DB, error) {
 	if databaseURL == "" {
-		return fmt.Errorf("database URL is required")
+		return nil, fmt.Errorf("database URL is required")
 	}
 
-	var err error
-	DB, err = gorm.Open(postgres.Open(databaseURL), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
+		Logger:         gormLogger,
+		TranslateError: true,
+		NowFunc:        NowUTC,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	// Spans are no-ops unless a tracer provider has been installed
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
 	}
 
-	return nil
+	return db, nil
 }
 
-func Close() error {
-	if DB == nil {
+func Close(db *gorm.DB) error {
+	if db == nil {
 		return nil
 	}
 
-	sqlDB, err := DB.DB()
+	sqlDB, err := db.DB()
 	if err != nil {
 		return err
 	}
@@ -39,12 +53,12 @@ func Close() error {
 	return sqlDB.Close()
 }
 
-func IsHealthy() error {
-	if DB == nil {
+func IsHealthy(db *gorm.DB) error {
+	if db == nil {
 		return fmt.Errorf("database connection is nil")
 	}
 
-	sqlDB, err := DB.DB()
+	sqlDB, err := db.DB()
 	if err != nil {
 		return err
 	}
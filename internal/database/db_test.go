@@ -0,0 +1,25 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
+)
+
+func TestNowUTC(t *testing.T) {
+	t.Run("return UTC when the host is in another timezone", func(t *testing.T) {
+		kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+		assert.NoError(t, err)
+		local := time.Local
+		time.Local = kiritimati
+		defer func() { time.Local = local }()
+
+		now := database.NowUTC()
+
+		assert.Equal(t, time.UTC, now.Location())
+		assert.WithinDuration(t, time.Now(), now, time.Second)
+	})
+}
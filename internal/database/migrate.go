@@ -0,0 +1,343 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// migrationLockID is the Postgres advisory lock key held while migrations run, so replicas
+// starting at the same time apply each migration exactly once
+const migrationLockID int64 = 4_207_351_902
+
+// migrationFilePattern matches NNN_description.up.sql and NNN_description.down.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a single versioned schema change with its rollback
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationState reports whether a single migration has been applied
+type MigrationState struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// MigrationStatus describes the schema version of a database and every known migration
+type MigrationStatus struct {
+	Version    uint
+	Dirty      bool
+	Migrations []MigrationState
+}
+
+// Migrator applies embedded SQL migrations, tracking progress in a schema_migrations table
+// compatible with golang-migrate so existing databases keep their recorded version
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: sqlDB, migrations: migrations}, nil
+}
+
+// LoadMigrations reads migration files from the root of fsys and pairs up and down halves by version
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(matches[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+
+		contents, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: matches[2]}
+			byVersion[uint(version)] = migration
+		}
+
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			migration.Up = string(contents)
+		} else {
+			migration.Down = string(contents)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration
+func (m *Migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down rolls back the given number of applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive")
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, _, err := currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		index := m.indexOf(current)
+		if current != 0 && index < 0 {
+			return fmt.Errorf("database version %d has no matching migration", current)
+		}
+
+		var target uint
+		if index-steps >= 0 {
+			target = m.migrations[index-steps].Version
+		}
+
+		return m.migrateTo(ctx, conn, target)
+	})
+}
+
+// To migrates up or down until the database is at the given version; zero rolls back everything
+func (m *Migrator) To(ctx context.Context, version uint) error {
+	if version != 0 && m.indexOf(version) < 0 {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return m.migrateTo(ctx, conn, version)
+	})
+}
+
+// Force records the given version as applied and clean without running any SQL,
+// for recovering after a migration was fixed by hand
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if version != 0 && m.indexOf(version) < 0 {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return setVersion(ctx, conn, version)
+	})
+}
+
+// Status reports the current schema version and which migrations have been applied
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	var status MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		var err error
+		status.Version, status.Dirty, err = currentVersion(ctx, conn)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, migration := range m.migrations {
+		status.Migrations = append(status.Migrations, MigrationState{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Applied: migration.Version <= status.Version,
+		})
+	}
+
+	return &status, nil
+}
+
+// migrateTo applies up or down migrations one at a time until target is reached; the caller holds the lock
+func (m *Migrator) migrateTo(ctx context.Context, conn *sql.Conn, target uint) error {
+	current, dirty, err := currentVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("database is dirty at version %d, fix the schema by hand and run migrate force", current)
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version <= current || migration.Version > target {
+			continue
+		}
+
+		if err := applyMigration(ctx, conn, migration.Up, migration.Version); err != nil {
+			return fmt.Errorf("migration %d_%s up failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version <= target || migration.Version > current {
+			continue
+		}
+
+		var previous uint
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+
+		if err := applyMigration(ctx, conn, migration.Down, previous); err != nil {
+			return fmt.Errorf("migration %d_%s down failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// indexOf returns the position of the migration with the given version, or -1
+func (m *Migrator) indexOf(version uint) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID) //nolint:errcheck
+
+	_, err = conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)")
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// currentVersion returns the recorded schema version, zero if no migration has been applied
+func currentVersion(ctx context.Context, conn *sql.Conn) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return uint(version), dirty, nil
+}
+
+// applyMigration runs a migration script and records the resulting version in one transaction
+func applyMigration(ctx context.Context, conn *sql.Conn, script string, version uint) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+
+	if err := writeVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// setVersion overwrites the recorded schema version
+func setVersion(ctx context.Context, conn *sql.Conn, version uint) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := writeVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// writeVersion stores version as the single clean row of schema_migrations, or no row for version zero
+func writeVersion(ctx context.Context, tx *sql.Tx, version uint) error {
+	if _, err := tx.ExecContext(ctx, "TRUNCATE schema_migrations"); err != nil {
+		return err
+	}
+
+	if version == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", int64(version))
+
+	return err
+}
@@ -0,0 +1,70 @@
+package database_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
+	"github.com/mindful-minutes/mindful-minutes-api/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("successfully load every embedded migration in version order", func(t *testing.T) {
+		loaded, err := database.LoadMigrations(migrations.FS)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, loaded)
+		assert.Equal(t, uint(1), loaded[0].Version)
+		assert.Equal(t, "create_users_table", loaded[0].Name)
+
+		for i := 1; i < len(loaded); i++ {
+			assert.Greater(t, loaded[i].Version, loaded[i-1].Version)
+		}
+	})
+
+	t.Run("pair up and down files by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"002_add_notes.up.sql":       {Data: []byte("ALTER TABLE things ADD COLUMN notes TEXT;")},
+			"002_add_notes.down.sql":     {Data: []byte("ALTER TABLE things DROP COLUMN notes;")},
+			"001_create_things.up.sql":   {Data: []byte("CREATE TABLE things (id SERIAL);")},
+			"001_create_things.down.sql": {Data: []byte("DROP TABLE things;")},
+			"README.md":                  {Data: []byte("ignored")},
+		}
+
+		loaded, err := database.LoadMigrations(fsys)
+
+		assert.NoError(t, err)
+		assert.Len(t, loaded, 2)
+		assert.Equal(t, uint(1), loaded[0].Version)
+		assert.Equal(t, "CREATE TABLE things (id SERIAL);", loaded[0].Up)
+		assert.Equal(t, "DROP TABLE things;", loaded[0].Down)
+		assert.Equal(t, uint(2), loaded[1].Version)
+		assert.Equal(t, "add_notes", loaded[1].Name)
+	})
+
+	t.Run("return error when down file is missing", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"001_create_things.up.sql": {Data: []byte("CREATE TABLE things (id SERIAL);")},
+		}
+
+		_, err := database.LoadMigrations(fsys)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must have both up and down files")
+	})
+
+	t.Run("return error when two migrations share a version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"001_create_things.up.sql":   {Data: []byte("CREATE TABLE things (id SERIAL);")},
+			"001_create_things.down.sql": {Data: []byte("DROP TABLE things;")},
+			"001_create_others.up.sql":   {Data: []byte("CREATE TABLE others (id SERIAL);")},
+		}
+
+		_, err := database.LoadMigrations(fsys)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is used by both")
+	})
+}
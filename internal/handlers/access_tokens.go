@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type CreateAccessTokenRequest struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	Scopes []string `json:"scopes" binding:"required,min=1"`
+}
+
+// CreateAccessToken issues a personal access token. The token itself is only ever returned here.
+func (h *Handler) CreateAccessToken(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	var req CreateAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Validation(err))
+
+		return
+	}
+
+	for _, scope := range req.Scopes {
+		if !slices.Contains(auth.Scopes, scope) {
+			apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidScope, "Invalid scope "+scope).
+				WithFields(apierror.FieldError{Field: "scopes", Message: "must be one of " + strings.Join(auth.Scopes, ", ")}))
+
+			return
+		}
+	}
+
+	token, hash, prefix, err := auth.GenerateAccessToken()
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to generate access token", err))
+
+		return
+	}
+
+	slices.Sort(req.Scopes)
+	accessToken := models.AccessToken{
+		UserID:    user.ID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		TokenHash: hash,
+		Scopes:    slices.Compact(req.Scopes),
+	}
+
+	if err := h.accessTokens.Create(c.Request.Context(), &accessToken); err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to create access token", err))
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":      "Access token created, copy it now as it won't be shown again",
+		"token":        token,
+		"access_token": accessToken,
+	})
+}
+
+// GetAccessTokens lists the authenticated user's personal access tokens without their secrets
+func (h *Handler) GetAccessTokens(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	tokens, err := h.accessTokens.ListForUser(c.Request.Context(), user.ID)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve access tokens", err))
+
+		return
+	}
+
+	if tokens == nil {
+		tokens = []models.AccessToken{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_tokens": tokens,
+	})
+}
+
+// RevokeAccessToken deletes one of the authenticated user's personal access tokens
+func (h *Handler) RevokeAccessToken(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	tokenID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidAccessTokenID, "Invalid access token ID"))
+
+		return
+	}
+
+	err = h.accessTokens.Delete(c.Request.Context(), user.ID, uint(tokenID))
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(http.StatusNotFound, apierror.CodeAccessTokenNotFound, "Access token not found"))
+
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to revoke access token", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Access token revoked successfully",
+	})
+}
@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(h *handlers.Handler, handle func(*handlers.Handler, *gin.Context), user *models.User, method, body string, params ...gin.Param) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/tokens", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = params
+		c.Set("user", *user)
+
+		handle(h, c)
+
+		return w
+	}
+
+	t.Run("successfully create token and store only its hash", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		h := handlers.New(repos)
+		user := testutils.CreateTestUser("test_clerk_id")
+
+		w := serve(h, (*handlers.Handler).CreateAccessToken, user, "POST", `{"name": "Shortcut", "scopes": ["sessions:write", "stats:read", "sessions:write"]}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response struct {
+			Token       string             `json:"token"`
+			AccessToken models.AccessToken `json:"access_token"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, strings.HasPrefix(response.Token, auth.AccessTokenPrefix))
+		assert.Equal(t, []string{"sessions:write", "stats:read"}, response.AccessToken.Scopes)
+		assert.NotContains(t, w.Body.String(), auth.HashAccessToken(response.Token))
+
+		stored, err := repos.AccessTokens.FindByHash(context.Background(), auth.HashAccessToken(response.Token))
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, stored.UserID)
+		assert.Equal(t, "Shortcut", stored.Name)
+	})
+
+	t.Run("return bad request when scope is unknown", func(t *testing.T) {
+		h := handlers.New(memory.NewRepositories())
+
+		w := serve(h, (*handlers.Handler).CreateAccessToken, testutils.CreateTestUser("test_clerk_id"), "POST", `{"name": "Shortcut", "scopes": ["admin"]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_SCOPE")
+	})
+
+	t.Run("return bad request when scopes are missing", func(t *testing.T) {
+		h := handlers.New(memory.NewRepositories())
+
+		w := serve(h, (*handlers.Handler).CreateAccessToken, testutils.CreateTestUser("test_clerk_id"), "POST", `{"name": "Shortcut", "scopes": []}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "VALIDATION_FAILED")
+	})
+
+	t.Run("list only the user's own tokens", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		h := handlers.New(repos)
+		user := testutils.CreateTestUser("test_clerk_id")
+		repos.AccessTokens.Create(context.Background(), &models.AccessToken{UserID: user.ID, Name: "Mine", TokenHash: "a", Scopes: []string{auth.ScopeStatsRead}})
+		repos.AccessTokens.Create(context.Background(), &models.AccessToken{UserID: "someone_else", Name: "Theirs", TokenHash: "b", Scopes: []string{auth.ScopeStatsRead}})
+
+		w := serve(h, (*handlers.Handler).GetAccessTokens, user, "GET", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Mine")
+		assert.NotContains(t, w.Body.String(), "Theirs")
+	})
+
+	t.Run("successfully revoke token", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		h := handlers.New(repos)
+		user := testutils.CreateTestUser("test_clerk_id")
+		token := &models.AccessToken{UserID: user.ID, Name: "Mine", TokenHash: "a", Scopes: []string{auth.ScopeStatsRead}}
+		repos.AccessTokens.Create(context.Background(), token)
+
+		w := serve(h, (*handlers.Handler).RevokeAccessToken, user, "DELETE", "", gin.Param{Key: "id", Value: "1"})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		tokens, _ := repos.AccessTokens.ListForUser(context.Background(), user.ID)
+		assert.Empty(t, tokens)
+	})
+
+	t.Run("return not found when revoking another user's token", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		h := handlers.New(repos)
+		repos.AccessTokens.Create(context.Background(), &models.AccessToken{UserID: "someone_else", Name: "Theirs", TokenHash: "b", Scopes: []string{auth.ScopeStatsRead}})
+
+		w := serve(h, (*handlers.Handler).RevokeAccessToken, testutils.CreateTestUser("test_clerk_id"), "DELETE", "", gin.Param{Key: "id", Value: "1"})
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "ACCESS_TOKEN_NOT_FOUND")
+	})
+}
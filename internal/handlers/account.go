@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+)
+
+// ExportAccount returns a zip archive of everything stored about the authenticated user
+func (h *Handler) ExportAccount(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	// Build the archive in memory so a failure can still be reported as a problem response
+	var archive bytes.Buffer
+	if err := h.accounts.Export(c.Request.Context(), user, &archive); err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to export account", err))
+
+		return
+	}
+
+	filename := "mindful-minutes-export-" + time.Now().UTC().Format(time.DateOnly) + ".zip"
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/zip", archive.Bytes())
+}
+
+// RequestAccountDeletion schedules the authenticated user's account for permanent deletion
+// once the grace period has passed. Until then the account works as usual.
+func (h *Handler) RequestAccountDeletion(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	if err := h.accounts.ScheduleDeletion(c.Request.Context(), user); err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to schedule account deletion", err))
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":      "Account deletion scheduled",
+		"delete_after": h.accounts.DeleteAfter(user),
+	})
+}
+
+// CancelAccountDeletion withdraws a pending deletion request
+func (h *Handler) CancelAccountDeletion(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	if err := h.accounts.CancelDeletion(c.Request.Context(), user); err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to cancel account deletion", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Account deletion cancelled",
+	})
+}
@@ -0,0 +1,82 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	serve := func(h *handlers.Handler, handle func(*handlers.Handler, *gin.Context), user *models.User, method string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(method, "/account", nil)
+		c.Set("user", *user)
+
+		handle(h, c)
+
+		return w
+	}
+
+	t.Run("return zip archive as attachment", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		require.NoError(t, repos.Users.Create(ctx, user))
+
+		w := serve(handlers.New(repos), (*handlers.Handler).ExportAccount, user, "POST")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Regexp(t, `^attachment; filename="mindful-minutes-export-\d{4}-\d{2}-\d{2}\.zip"$`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "PK", w.Body.String()[:2])
+	})
+
+	t.Run("schedule deletion after the grace period", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		require.NoError(t, repos.Users.Create(ctx, user))
+		h := handlers.New(repos, handlers.WithDeletionGracePeriod(30*24*time.Hour))
+
+		w := serve(h, (*handlers.Handler).RequestAccountDeletion, user, "POST")
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var response struct {
+			DeleteAfter time.Time `json:"delete_after"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), response.DeleteAfter, time.Minute)
+
+		stored, err := repos.Users.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored.DeletionRequestedAt)
+	})
+
+	t.Run("cancel pending deletion", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		requestedAt := time.Now()
+		user.DeletionRequestedAt = &requestedAt
+		require.NoError(t, repos.Users.Create(ctx, user))
+
+		w := serve(handlers.New(repos), (*handlers.Handler).CancelAccountDeletion, user, "DELETE")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		stored, err := repos.Users.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.DeletionRequestedAt)
+	})
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+)
+
+// GetAchievements lists all badges with the authenticated user's progress towards each
+func (h *Handler) GetAchievements(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	achievements, err := h.achievements.GetAchievements(c.Request.Context(), user.ID)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve achievements", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"achievements": achievements,
+	})
+}
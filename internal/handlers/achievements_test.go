@@ -0,0 +1,156 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/constants"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAchievements(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repos := memory.NewRepositories()
+	h := handlers.New(repos)
+
+	// Helper function to reset repositories before each test
+	resetRepos := func() {
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
+	}
+
+	createSession := func(user *models.User, sessionType string) *httptest.ResponseRecorder {
+		jsonData, _ := json.Marshal(map[string]interface{}{
+			"duration_seconds": 600,
+			"session_type":     sessionType,
+		})
+		req := httptest.NewRequest("POST", "/sessions", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Set("user", *user)
+
+		h.CreateSession(c)
+
+		return w
+	}
+
+	t.Run("award first session badge once when session created", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+
+		w := createSession(testUser, constants.SessionTypeMindfulness)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response struct {
+			Session         models.Session           `json:"session"`
+			NewAchievements []models.UserAchievement `json:"new_achievements"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.NewAchievements, 1)
+		assert.Equal(t, "first_session", response.NewAchievements[0].BadgeKey)
+		assert.Equal(t, response.Session.ID, *response.NewAchievements[0].SessionID)
+
+		w = createSession(testUser, constants.SessionTypeBreathing)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"new_achievements":[]`)
+
+		achievements, err := repos.Achievements.ListForUser(context.Background(), testUser.ID)
+		assert.NoError(t, err)
+		assert.Len(t, achievements, 1)
+	})
+
+	t.Run("award explorer badge when every session type tried", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+
+		var w *httptest.ResponseRecorder
+		for _, sessionType := range constants.SessionTypes {
+			w = createSession(testUser, sessionType)
+			assert.Equal(t, http.StatusCreated, w.Code)
+		}
+
+		assert.Contains(t, w.Body.String(), "explorer")
+	})
+
+	t.Run("list badges with progress towards unearned ones", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+
+		for i := 0; i < 3; i++ {
+			session := models.Session{
+				UserID:          testUser.ID,
+				DurationSeconds: 600,
+				SessionType:     constants.SessionTypeMindfulness,
+				CreatedAt:       time.Date(2025, 5, 10+i, 5, 30, 0, 0, time.UTC),
+			}
+			repos.Sessions.Create(context.Background(), &session)
+		}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/achievements", nil)
+		c.Set("user", *testUser)
+
+		h.GetAchievements(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Achievements []services.AchievementProgress `json:"achievements"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Achievements, len(services.Badges))
+
+		progress := make(map[string]services.AchievementProgress)
+		for _, achievement := range response.Achievements {
+			progress[achievement.Key] = achievement
+		}
+
+		assert.False(t, progress["streak_7"].Earned)
+		assert.Equal(t, 3, progress["streak_7"].Progress)
+		assert.Equal(t, 7, progress["streak_7"].Target)
+		assert.Equal(t, 3, progress["early_bird"].Progress)
+		assert.Equal(t, 1, progress["explorer"].Progress)
+
+		// 05:30 UTC is after 6am in Berlin
+		preferences := models.DefaultPreferences(testUser.ID)
+		preferences.Timezone = "Europe/Berlin"
+		repos.Preferences.Save(context.Background(), preferences)
+
+		stats, err := services.NewAchievementService(repos.Sessions, repos.Achievements, repos.Preferences).GetAchievementStats(context.Background(), testUser.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, stats.EarlyMorningSessions)
+	})
+
+	t.Run("return unauthorized when user not in context", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/achievements", nil)
+
+		h.GetAchievements(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "User not found")
+	})
+}
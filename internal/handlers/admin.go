@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+// AdminStatsResponse holds service-wide totals for support staff
+type AdminStatsResponse struct {
+	Users           int     `json:"users"`
+	DeletedUsers    int     `json:"deleted_users"`
+	Sessions        int     `json:"sessions"`
+	DeletedSessions int     `json:"deleted_sessions"`
+	TotalHours      float64 `json:"total_hours"`
+	Achievements    int     `json:"achievements"`
+	WebhookEvents   int     `json:"webhook_events"`
+}
+
+// AdminSearchUsers finds users by id, email or clerk_user_id, including soft-deleted users.
+// At least one query parameter is required so the whole table can't be listed by accident.
+func (h *Handler) AdminSearchUsers(c *gin.Context) {
+	search := repository.UserSearch{
+		ID:          c.Query("id"),
+		Email:       c.Query("email"),
+		ClerkUserID: c.Query("clerk_user_id"),
+	}
+	if search == (repository.UserSearch{}) {
+		apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "One of id, email or clerk_user_id is required"))
+
+		return
+	}
+
+	users, err := h.users.Search(c.Request.Context(), search)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to search users", err))
+
+		return
+	}
+	if users == nil {
+		users = []models.User{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
+
+// AdminGetUser returns a user by ID, including soft-deleted users
+func (h *Handler) AdminGetUser(c *gin.Context) {
+	user, ok := h.adminTargetUser(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// AdminGetUserSessions returns every session of a user, including soft-deleted ones
+func (h *Handler) AdminGetUserSessions(c *gin.Context) {
+	user, ok := h.adminTargetUser(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessions.ListWithDeleted(c.Request.Context(), user.ID)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve sessions", err))
+
+		return
+	}
+	if sessions == nil {
+		sessions = []models.Session{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+// AdminGetUserStats returns a user's personal records and lifetime milestones
+func (h *Handler) AdminGetUserStats(c *gin.Context) {
+	user, ok := h.adminTargetUser(c)
+	if !ok {
+		return
+	}
+
+	records, err := h.analytics.GetRecords(c.Request.Context(), user.ID)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve records", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
+
+// AdminRestoreUser undoes the soft delete applied by the Clerk user.deleted webhook
+func (h *Handler) AdminRestoreUser(c *gin.Context) {
+	user, err := h.users.Restore(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(http.StatusNotFound, apierror.CodeUserNotFound, "No deleted user with that ID"))
+
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to restore user", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User restored successfully", "user": user})
+}
+
+// AdminRestoreSession undoes the soft delete of one of a user's sessions
+func (h *Handler) AdminRestoreSession(c *gin.Context) {
+	user, ok := h.adminTargetUser(c)
+	if !ok {
+		return
+	}
+
+	sessionID, err := strconv.ParseUint(c.Param("session_id"), 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidSessionID, "Invalid session ID"))
+
+		return
+	}
+
+	session, err := h.sessions.Restore(c.Request.Context(), user.ID, uint(sessionID))
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(http.StatusNotFound, apierror.CodeSessionNotFound, "No deleted session with that ID"))
+
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to restore session", err))
+
+		return
+	}
+
+	// The restored session changes that year's totals
+	if err := h.reviews.InvalidateYearReview(c.Request.Context(), user.ID, session.CreatedAt); err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to invalidate year review", slog.String("error", err.Error()))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session restored successfully", "session": session})
+}
+
+// AdminGetStats returns service-wide usage totals
+func (h *Handler) AdminGetStats(c *gin.Context) {
+	totals, err := h.stats.Totals(c.Request.Context())
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve stats", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, AdminStatsResponse{
+		Users:           totals.Users,
+		DeletedUsers:    totals.DeletedUsers,
+		Sessions:        totals.Sessions,
+		DeletedSessions: totals.DeletedSessions,
+		TotalHours:      float64(totals.TotalSeconds) / 3600.0,
+		Achievements:    totals.Achievements,
+		WebhookEvents:   totals.WebhookEvents,
+	})
+}
+
+// adminTargetUser loads the user named by the :id parameter, including soft-deleted users,
+// aborting with 404 if there is none
+func (h *Handler) adminTargetUser(c *gin.Context) (*models.User, bool) {
+	users, err := h.users.Search(c.Request.Context(), repository.UserSearch{ID: c.Param("id")})
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve user", err))
+
+		return nil, false
+	}
+	if len(users) == 0 {
+		apierror.Abort(c, apierror.New(http.StatusNotFound, apierror.CodeUserNotFound, "User not found"))
+
+		return nil, false
+	}
+
+	return &users[0], true
+}
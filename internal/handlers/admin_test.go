@@ -0,0 +1,175 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	serve := func(h *handlers.Handler, handle func(*handlers.Handler, *gin.Context), method, target string, params ...gin.Param) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(method, target, nil)
+		c.Params = params
+
+		handle(h, c)
+
+		return w
+	}
+
+	// setup stores a deleted user with one live and one deleted session
+	setup := func() (*repository.Repositories, *models.User, *models.Session, *models.Session) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		require.NoError(t, repos.Users.Create(ctx, user))
+
+		live := testutils.CreateTestSession(user.ID)
+		require.NoError(t, repos.Sessions.Create(ctx, live))
+		deleted := testutils.CreateTestSession(user.ID)
+		require.NoError(t, repos.Sessions.Create(ctx, deleted))
+		require.NoError(t, repos.Sessions.Delete(ctx, deleted))
+		require.NoError(t, repos.Users.DeleteByClerkUserID(ctx, "user_123"))
+
+		return repos, user, live, deleted
+	}
+
+	t.Run("search users including deleted ones", func(t *testing.T) {
+		repos, user, _, _ := setup()
+
+		w := serve(handlers.New(repos), (*handlers.Handler).AdminSearchUsers, "GET", "/users?email=TEST@example.com")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Users []models.User `json:"users"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Users, 1)
+		assert.Equal(t, user.ID, response.Users[0].ID)
+		assert.True(t, response.Users[0].DeletedAt.Valid)
+	})
+
+	t.Run("return empty list when no user matches", func(t *testing.T) {
+		repos, _, _, _ := setup()
+
+		w := serve(handlers.New(repos), (*handlers.Handler).AdminSearchUsers, "GET", "/users?clerk_user_id=nobody")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"users": []}`, w.Body.String())
+	})
+
+	t.Run("return bad request when search has no criteria", func(t *testing.T) {
+		repos, _, _, _ := setup()
+
+		w := serve(handlers.New(repos), (*handlers.Handler).AdminSearchUsers, "GET", "/users")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "VALIDATION_FAILED")
+	})
+
+	t.Run("return not found when user does not exist", func(t *testing.T) {
+		repos, _, _, _ := setup()
+
+		w := serve(handlers.New(repos), (*handlers.Handler).AdminGetUser, "GET", "/users/missing", gin.Param{Key: "id", Value: "missing"})
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "USER_NOT_FOUND")
+	})
+
+	t.Run("list sessions including deleted ones", func(t *testing.T) {
+		repos, user, _, _ := setup()
+
+		w := serve(handlers.New(repos), (*handlers.Handler).AdminGetUserSessions, "GET", "/users/"+user.ID+"/sessions", gin.Param{Key: "id", Value: user.ID})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Sessions []models.Session `json:"sessions"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Sessions, 2)
+	})
+
+	t.Run("return user stats", func(t *testing.T) {
+		repos, user, _, _ := setup()
+
+		w := serve(handlers.New(repos), (*handlers.Handler).AdminGetUserStats, "GET", "/users/"+user.ID+"/stats", gin.Param{Key: "id", Value: user.ID})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("restore deleted user", func(t *testing.T) {
+		repos, user, _, _ := setup()
+		h := handlers.New(repos)
+
+		w := serve(h, (*handlers.Handler).AdminRestoreUser, "POST", "/users/"+user.ID+"/restore", gin.Param{Key: "id", Value: user.ID})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		_, err := repos.Users.FindByClerkUserID(ctx, "user_123")
+		assert.NoError(t, err)
+
+		w = serve(h, (*handlers.Handler).AdminRestoreUser, "POST", "/users/"+user.ID+"/restore", gin.Param{Key: "id", Value: user.ID})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("restore deleted session", func(t *testing.T) {
+		repos, user, _, deleted := setup()
+		sessionID := fmt.Sprint(deleted.ID)
+
+		w := serve(handlers.New(repos), (*handlers.Handler).AdminRestoreSession, "POST", "/users/"+user.ID+"/sessions/"+sessionID+"/restore",
+			gin.Param{Key: "id", Value: user.ID}, gin.Param{Key: "session_id", Value: sessionID})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		_, err := repos.Sessions.FindForUser(ctx, user.ID, deleted.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("return not found when session is not deleted", func(t *testing.T) {
+		repos, user, live, _ := setup()
+		sessionID := fmt.Sprint(live.ID)
+
+		w := serve(handlers.New(repos), (*handlers.Handler).AdminRestoreSession, "POST", "/users/"+user.ID+"/sessions/"+sessionID+"/restore",
+			gin.Param{Key: "id", Value: user.ID}, gin.Param{Key: "session_id", Value: sessionID})
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "SESSION_NOT_FOUND")
+	})
+
+	t.Run("return bad request when session id is invalid", func(t *testing.T) {
+		repos, user, _, _ := setup()
+
+		w := serve(handlers.New(repos), (*handlers.Handler).AdminRestoreSession, "POST", "/users/"+user.ID+"/sessions/abc/restore",
+			gin.Param{Key: "id", Value: user.ID}, gin.Param{Key: "session_id", Value: "abc"})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_SESSION_ID")
+	})
+
+	t.Run("return system wide stats", func(t *testing.T) {
+		repos, _, _, _ := setup()
+
+		w := serve(handlers.New(repos), (*handlers.Handler).AdminGetStats, "GET", "/stats")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response handlers.AdminStatsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 0, response.Users)
+		assert.Equal(t, 1, response.DeletedUsers)
+		assert.Equal(t, 1, response.Sessions)
+		assert.Equal(t, 1, response.DeletedSessions)
+	})
+}
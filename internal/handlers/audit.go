@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/audit"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+)
+
+type GetAuditEventsResponse struct {
+	AuditEvents []models.AuditEvent `json:"audit_events"`
+	NextID      *uint               `json:"next_id,omitempty"`
+	HasMore     bool                `json:"has_more"`
+}
+
+// staffActors are actors whose identity and IP are kept from the account owner
+var staffActors = map[string]bool{
+	audit.ActorAdmin:    true,
+	audit.ActorOperator: true,
+}
+
+// GetAuditEvents returns the authenticated user's account history with cursor-based pagination
+func (h *Handler) GetAuditEvents(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	// Parse pagination parameters
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var lastID uint
+	if lastIDStr := c.Query("last_id"); lastIDStr != "" {
+		if id, err := strconv.ParseUint(lastIDStr, 10, 32); err == nil {
+			lastID = uint(id)
+		}
+	}
+
+	// Fetch one extra event to detect whether another page exists
+	events, err := h.auditEvents.ListForUser(c.Request.Context(), user.ID, lastID, limit+1)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve audit events", err))
+
+		return
+	}
+
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+	if events == nil {
+		events = []models.AuditEvent{}
+	}
+
+	var nextID *uint
+	if hasMore && len(events) > 0 {
+		nextID = &events[len(events)-1].ID
+	}
+
+	for i := range events {
+		if staffActors[events[i].ActorType] {
+			events[i].ActorID = ""
+			events[i].IP = ""
+		}
+	}
+
+	c.JSON(http.StatusOK, GetAuditEventsResponse{
+		AuditEvents: events,
+		NextID:      nextID,
+		HasMore:     hasMore,
+	})
+}
@@ -0,0 +1,86 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/audit"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAuditEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	serve := func(h *handlers.Handler, user *models.User, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", target, nil)
+		c.Set("user", *user)
+
+		h.GetAuditEvents(c)
+
+		return w
+	}
+
+	t.Run("page through own events newest first", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		other := testutils.CreateTestUser("user_456")
+		for _, event := range []*models.AuditEvent{
+			{UserID: user.ID, ActorType: audit.ActorUser, ActorID: user.ID, Action: "session.created", TargetType: "session", TargetID: "1"},
+			{UserID: other.ID, ActorType: audit.ActorUser, ActorID: other.ID, Action: "session.created", TargetType: "session", TargetID: "2"},
+			{UserID: user.ID, ActorType: audit.ActorUser, ActorID: user.ID, Action: "session.deleted", TargetType: "session", TargetID: "1"},
+		} {
+			require.NoError(t, repos.AuditEvents.Create(ctx, event))
+		}
+		h := handlers.New(repos)
+
+		w := serve(h, user, "/audit?limit=1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page handlers.GetAuditEventsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.AuditEvents, 1)
+		assert.Equal(t, "session.deleted", page.AuditEvents[0].Action)
+		assert.True(t, page.HasMore)
+
+		w = serve(h, user, "/audit?limit=1&last_id="+strconv.Itoa(int(*page.NextID)))
+
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.AuditEvents, 1)
+		assert.Equal(t, "session.created", page.AuditEvents[0].Action)
+		assert.False(t, page.HasMore)
+	})
+
+	t.Run("hide support staff identity and IP", func(t *testing.T) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		require.NoError(t, repos.AuditEvents.Create(ctx, &models.AuditEvent{
+			UserID: user.ID, ActorType: audit.ActorAdmin, ActorID: "admin_1", Action: "user.restored", TargetType: "user", TargetID: user.ID, IP: "10.0.0.1",
+		}))
+
+		w := serve(handlers.New(repos), user, "/audit")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"actor_type":"admin","actor_id":""`)
+		assert.NotContains(t, w.Body.String(), "10.0.0.1")
+	})
+
+	t.Run("return empty list without history", func(t *testing.T) {
+		w := serve(handlers.New(memory.NewRepositories()), testutils.CreateTestUser("user_123"), "/audit")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"audit_events": [], "has_more": false}`, w.Body.String())
+	})
+}
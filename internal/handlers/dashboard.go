@@ -5,18 +5,18 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
-	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
 )
 
 // GetDashboard returns all dashboard data for the authenticated user
 // Query parameters:
 // - year: Year for yearly progress (defaults to current year)
 // - sessions: Number of recent sessions to return (defaults to 5, max 100)
-func GetDashboard(c *gin.Context) {
+func (h *Handler) GetDashboard(c *gin.Context) {
 	user := auth.GetCurrentUser(c)
 	if user == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
 
 		return
 	}
@@ -24,9 +24,9 @@ func GetDashboard(c *gin.Context) {
 	year := parseYear(c)
 	sessionLimit := parseSessionLimit(c)
 
-	dashboardData, err := services.GetDashboardData(user, year, sessionLimit)
+	dashboardData, err := h.analytics.GetDashboardData(c.Request.Context(), user, year, sessionLimit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve dashboard data", "details": err.Error()})
+		apierror.Abort(c, apierror.Internal("Failed to retrieve dashboard data", err))
 
 		return
 	}
@@ -62,4 +62,4 @@ func parseSessionLimit(c *gin.Context) int {
 	}
 
 	return limit
-}
\ No newline at end of file
+}
@@ -1,44 +1,44 @@
 package handlers_test
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGetDashboard(t *testing.T) {
-	// Setup test database
-	db := testutils.SetupTestDB(t)
-	defer testutils.CleanupTestDB(t, db)
-
-	// Set the global database connection for services to use
-	database.DB = db
+	// Setup in-memory repositories
+	repos := memory.NewRepositories()
+	h := handlers.New(repos)
 
 	// Setup Gin in test mode
 	gin.SetMode(gin.TestMode)
 
 	t.Run("return dashboard data when user is authenticated", func(t *testing.T) {
-		testutils.TruncateTable(db, "sessions")
-		testutils.TruncateTable(db, "users")
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
 
 		// Create test user
 		user := &models.User{
-			ID:           "01JAXXXXXXXXXXXXXXXXXXX1",
-			ClerkUserID:  "user_test123",
-			Email:        "test@example.com",
-			FirstName:    lo.ToPtr("Test"),
-			LastName:     lo.ToPtr("User"),
+			ID:          "01JAXXXXXXXXXXXXXXXXXXX1",
+			ClerkUserID: "user_test123",
+			Email:       "test@example.com",
+			FirstName:   lo.ToPtr("Test"),
+			LastName:    lo.ToPtr("User"),
 		}
-		err := db.Create(user).Error
+		err := repos.Users.Create(context.Background(), user)
 		assert.NoError(t, err)
 
 		// Create test sessions
@@ -47,19 +47,19 @@ func TestGetDashboard(t *testing.T) {
 				UserID:          user.ID,
 				DurationSeconds: 600,
 				SessionType:     "mindfulness",
-				Notes:          "Morning session",
-				CreatedAt:      time.Date(2025, 7, 5, 8, 0, 0, 0, time.UTC),
+				Notes:           "Morning session",
+				CreatedAt:       time.Date(2025, 7, 5, 8, 0, 0, 0, time.UTC),
 			},
 			{
 				UserID:          user.ID,
 				DurationSeconds: 900,
 				SessionType:     "breathing",
-				Notes:          "Evening session",
-				CreatedAt:      time.Date(2025, 7, 4, 20, 0, 0, 0, time.UTC),
+				Notes:           "Evening session",
+				CreatedAt:       time.Date(2025, 7, 4, 20, 0, 0, 0, time.UTC),
 			},
 		}
 		for _, session := range sessions {
-			err := db.Create(&session).Error
+			err := repos.Sessions.Create(context.Background(), &session)
 			assert.NoError(t, err)
 		}
 
@@ -72,11 +72,11 @@ func TestGetDashboard(t *testing.T) {
 		c.Set("user", *user)
 
 		// Call handler
-		handlers.GetDashboard(c)
+		h.GetDashboard(c)
 
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code)
-		
+
 		// Verify response contains expected structure
 		body := w.Body.String()
 		assert.Contains(t, body, "user")
@@ -88,18 +88,18 @@ func TestGetDashboard(t *testing.T) {
 	})
 
 	t.Run("return dashboard data with custom year parameter", func(t *testing.T) {
-		testutils.TruncateTable(db, "sessions")
-		testutils.TruncateTable(db, "users")
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
 
 		// Create test user
 		user := &models.User{
-			ID:           "01JAXXXXXXXXXXXXXXXXXXX2",
-			ClerkUserID:  "user_test456",
-			Email:        "test2@example.com",
-			FirstName:    lo.ToPtr("Test2"),
-			LastName:     lo.ToPtr("User2"),
+			ID:          "01JAXXXXXXXXXXXXXXXXXXX2",
+			ClerkUserID: "user_test456",
+			Email:       "test2@example.com",
+			FirstName:   lo.ToPtr("Test2"),
+			LastName:    lo.ToPtr("User2"),
 		}
-		err := db.Create(user).Error
+		err := repos.Users.Create(context.Background(), user)
 		assert.NoError(t, err)
 
 		// Setup request with year parameter
@@ -111,11 +111,11 @@ func TestGetDashboard(t *testing.T) {
 		c.Set("user", *user)
 
 		// Call handler
-		handlers.GetDashboard(c)
+		h.GetDashboard(c)
 
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code)
-		
+
 		// Verify response structure
 		body := w.Body.String()
 		assert.Contains(t, body, "yearly_progress")
@@ -123,18 +123,18 @@ func TestGetDashboard(t *testing.T) {
 	})
 
 	t.Run("return dashboard data with custom sessions limit", func(t *testing.T) {
-		testutils.TruncateTable(db, "sessions")
-		testutils.TruncateTable(db, "users")
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
 
 		// Create test user
 		user := &models.User{
-			ID:           "01JAXXXXXXXXXXXXXXXXXXX3",
-			ClerkUserID:  "user_test789",
-			Email:        "test3@example.com",
-			FirstName:    lo.ToPtr("Test3"),
-			LastName:     lo.ToPtr("User3"),
+			ID:          "01JAXXXXXXXXXXXXXXXXXXX3",
+			ClerkUserID: "user_test789",
+			Email:       "test3@example.com",
+			FirstName:   lo.ToPtr("Test3"),
+			LastName:    lo.ToPtr("User3"),
 		}
-		err := db.Create(user).Error
+		err := repos.Users.Create(context.Background(), user)
 		assert.NoError(t, err)
 
 		// Create multiple test sessions
@@ -143,10 +143,10 @@ func TestGetDashboard(t *testing.T) {
 				UserID:          user.ID,
 				DurationSeconds: 300 + i*60,
 				SessionType:     "mindfulness",
-				Notes:          "Session " + string(rune(i+'1')),
-				CreatedAt:      time.Now().AddDate(0, 0, -i),
+				Notes:           "Session " + string(rune(i+'1')),
+				CreatedAt:       time.Now().AddDate(0, 0, -i),
 			}
-			err := db.Create(&session).Error
+			err := repos.Sessions.Create(context.Background(), &session)
 			assert.NoError(t, err)
 		}
 
@@ -159,11 +159,11 @@ func TestGetDashboard(t *testing.T) {
 		c.Set("user", *user)
 
 		// Call handler
-		handlers.GetDashboard(c)
+		h.GetDashboard(c)
 
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code)
-		
+
 		// Verify response contains limited sessions
 		body := w.Body.String()
 		assert.Contains(t, body, "recent_sessions")
@@ -177,7 +177,7 @@ func TestGetDashboard(t *testing.T) {
 		c.Request = httptest.NewRequest("GET", "/api/dashboard", nil)
 
 		// Call handler without setting user in context
-		handlers.GetDashboard(c)
+		h.GetDashboard(c)
 
 		// Assertions
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
@@ -185,18 +185,18 @@ func TestGetDashboard(t *testing.T) {
 	})
 
 	t.Run("handle invalid year parameter gracefully", func(t *testing.T) {
-		testutils.TruncateTable(db, "sessions")
-		testutils.TruncateTable(db, "users")
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
 
 		// Create test user
 		user := &models.User{
-			ID:           "01JAXXXXXXXXXXXXXXXXXXX4",
-			ClerkUserID:  "user_test000",
-			Email:        "test4@example.com",
-			FirstName:    lo.ToPtr("Test4"),
-			LastName:     lo.ToPtr("User4"),
+			ID:          "01JAXXXXXXXXXXXXXXXXXXX4",
+			ClerkUserID: "user_test000",
+			Email:       "test4@example.com",
+			FirstName:   lo.ToPtr("Test4"),
+			LastName:    lo.ToPtr("User4"),
 		}
-		err := db.Create(user).Error
+		err := repos.Users.Create(context.Background(), user)
 		assert.NoError(t, err)
 
 		// Setup request with invalid year
@@ -208,7 +208,7 @@ func TestGetDashboard(t *testing.T) {
 		c.Set("user", *user)
 
 		// Call handler
-		handlers.GetDashboard(c)
+		h.GetDashboard(c)
 
 		// Should still return OK with default year
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -216,18 +216,18 @@ func TestGetDashboard(t *testing.T) {
 	})
 
 	t.Run("handle invalid sessions parameter gracefully", func(t *testing.T) {
-		testutils.TruncateTable(db, "sessions")
-		testutils.TruncateTable(db, "users")
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
 
 		// Create test user
 		user := &models.User{
-			ID:           "01JAXXXXXXXXXXXXXXXXXXX5",
-			ClerkUserID:  "user_test111",
-			Email:        "test5@example.com",
-			FirstName:    lo.ToPtr("Test5"),
-			LastName:     lo.ToPtr("User5"),
+			ID:          "01JAXXXXXXXXXXXXXXXXXXX5",
+			ClerkUserID: "user_test111",
+			Email:       "test5@example.com",
+			FirstName:   lo.ToPtr("Test5"),
+			LastName:    lo.ToPtr("User5"),
 		}
-		err := db.Create(user).Error
+		err := repos.Users.Create(context.Background(), user)
 		assert.NoError(t, err)
 
 		// Setup request with invalid sessions limit
@@ -239,10 +239,89 @@ func TestGetDashboard(t *testing.T) {
 		c.Set("user", *user)
 
 		// Call handler
-		handlers.GetDashboard(c)
+		h.GetDashboard(c)
 
 		// Should still return OK with default limit
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Contains(t, w.Body.String(), "recent_sessions")
 	})
-}
\ No newline at end of file
+
+	t.Run("return trends comparing this week to last week", func(t *testing.T) {
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
+
+		user := testutils.CreateTestUser("user_test222")
+		err := repos.Users.Create(context.Background(), user)
+		assert.NoError(t, err)
+
+		sessions := []models.Session{
+			{UserID: user.ID, DurationSeconds: 1200, SessionType: "mindfulness", CreatedAt: time.Now()},
+			{UserID: user.ID, DurationSeconds: 600, SessionType: "breathing", CreatedAt: time.Now().AddDate(0, 0, -7)},
+		}
+		for _, session := range sessions {
+			err := repos.Sessions.Create(context.Background(), &session)
+			assert.NoError(t, err)
+		}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/dashboard", nil)
+		c.Set("user", *user)
+
+		h.GetDashboard(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response services.DashboardData
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		week := response.Trends.Week
+		assert.Equal(t, 20, week.Current.Minutes)
+		assert.Equal(t, 1, week.Current.Sessions)
+		assert.Equal(t, 10, week.Previous.Minutes)
+		assert.Equal(t, 100.0, *week.MinutesChangePercent)
+		assert.Contains(t, response.Trends.Insights, services.Insight{
+			Type:    "weekly_increase",
+			Message: "You meditated 100% more this week than at this point last week",
+		})
+	})
+
+	t.Run("count sessions towards the day in the user's timezone", func(t *testing.T) {
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
+
+		user := testutils.CreateTestUser("user_test333")
+		err := repos.Users.Create(context.Background(), user)
+		assert.NoError(t, err)
+		preferences := models.DefaultPreferences(user.ID)
+		preferences.Timezone = "Pacific/Kiritimati"
+		assert.NoError(t, repos.Preferences.Save(context.Background(), preferences))
+
+		// Half past midnight in UTC+14 is still the previous day in UTC
+		loc, err := time.LoadLocation("Pacific/Kiritimati")
+		assert.NoError(t, err)
+		now := time.Now().In(loc)
+		createdAt := time.Date(now.Year(), now.Month(), now.Day(), 0, 30, 0, 0, loc)
+		session := models.Session{UserID: user.ID, DurationSeconds: 600, SessionType: "mindfulness", CreatedAt: createdAt}
+		assert.NoError(t, repos.Sessions.Create(context.Background(), &session))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/dashboard", nil)
+		c.Set("user", *user)
+
+		h.GetDashboard(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response services.DashboardData
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		today := response.WeeklyProgress[len(response.WeeklyProgress)-1]
+		assert.Equal(t, createdAt.Format("2006-01-02"), today.Date)
+		assert.Equal(t, 10, today.Minutes)
+		assert.Equal(t, 1, response.Streaks.Current)
+	})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
+)
+
+// Handler serves the authenticated API routes using explicitly injected dependencies
+type Handler struct {
+	users        repository.UserRepository
+	sessions     repository.SessionRepository
+	accessTokens repository.AccessTokenRepository
+	auditEvents  repository.AuditEventRepository
+	preferences  repository.PreferencesRepository
+	reminders    repository.ReminderRepository
+	stats        repository.StatsRepository
+	analytics    *services.AnalyticsService
+	achievements *services.AchievementService
+	reviews      *services.ReviewService
+	accounts     *services.AccountService
+}
+
+// Option customises a Handler in New
+type Option func(*options)
+
+type options struct {
+	deletionGracePeriod time.Duration
+}
+
+// WithDeletionGracePeriod sets how long deleted accounts are kept before they are purged.
+// Without it deletions become due for purging at once.
+func WithDeletionGracePeriod(gracePeriod time.Duration) Option {
+	return func(o *options) {
+		o.deletionGracePeriod = gracePeriod
+	}
+}
+
+// New builds a Handler and the services it depends on from the given repositories
+func New(repos *repository.Repositories, opts ...Option) *Handler {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Handler{
+		users:        repos.Users,
+		sessions:     repos.Sessions,
+		accessTokens: repos.AccessTokens,
+		auditEvents:  repos.AuditEvents,
+		preferences:  repos.Preferences,
+		reminders:    repos.Reminders,
+		stats:        repos.Stats,
+		analytics:    services.NewAnalyticsService(repos.Sessions, repos.Preferences),
+		achievements: services.NewAchievementService(repos.Sessions, repos.Achievements, repos.Preferences),
+		reviews:      services.NewReviewService(repos.Sessions, repos.YearReviews, repos.Preferences),
+		accounts:     services.NewAccountService(repos, o.deletionGracePeriod),
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/constants"
+	"golang.org/x/text/language"
+)
+
+// UpdatePreferencesRequest changes only the fields that are present
+type UpdatePreferencesRequest struct {
+	Timezone               *string `json:"timezone"`
+	Locale                 *string `json:"locale"`
+	WeekStart              *string `json:"week_start" binding:"omitempty,oneof=monday saturday sunday"`
+	DefaultSessionType     *string `json:"default_session_type"`
+	DefaultDurationSeconds *int    `json:"default_duration_seconds" binding:"omitempty,min=60,max=14400"`
+	Units                  *string `json:"units" binding:"omitempty,oneof=metric imperial"`
+	RemindersEnabled       *bool   `json:"reminders_enabled"`
+	StreakRemindersEnabled *bool   `json:"streak_reminders_enabled"`
+	// QuietHoursStart and QuietHoursEnd are set together; empty strings clear them
+	QuietHoursStart     *string `json:"quiet_hours_start"`
+	QuietHoursEnd       *string `json:"quiet_hours_end"`
+	ShareAnonymousUsage *bool   `json:"share_anonymous_usage"`
+}
+
+// GetPreferences returns the authenticated user's preferences, or the defaults if they never changed any
+func (h *Handler) GetPreferences(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	preferences, err := h.preferences.Get(c.Request.Context(), user.ID)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve preferences", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preferences": preferences,
+	})
+}
+
+// UpdatePreferences changes the authenticated user's preferences, leaving omitted fields as they are
+func (h *Handler) UpdatePreferences(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	var req UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Validation(err))
+
+		return
+	}
+
+	if req.DefaultSessionType != nil && !isValidSessionType(*req.DefaultSessionType) {
+		apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidSessionType, "Invalid session type").
+			WithFields(apierror.FieldError{Field: "default_session_type", Message: "must be one of " + strings.Join(constants.SessionTypes, ", ")}))
+
+		return
+	}
+
+	// "Local" would mean the server's timezone rather than the user's
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" || *req.Timezone == "Local" {
+			apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "Invalid request data").
+				WithFields(apierror.FieldError{Field: "timezone", Message: "must be an IANA timezone such as Europe/Berlin"}))
+
+			return
+		}
+	}
+
+	var locale string
+	if req.Locale != nil {
+		tag, err := language.Parse(*req.Locale)
+		if err != nil {
+			apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "Invalid request data").
+				WithFields(apierror.FieldError{Field: "locale", Message: "must be a language tag such as en-US"}))
+
+			return
+		}
+		locale = tag.String()
+	}
+
+	if (req.QuietHoursStart == nil) != (req.QuietHoursEnd == nil) ||
+		(req.QuietHoursStart != nil && (*req.QuietHoursStart == "") != (*req.QuietHoursEnd == "")) {
+		apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "Invalid request data").
+			WithFields(apierror.FieldError{Field: "quiet_hours_end", Message: "must be set or cleared together with quiet_hours_start"}))
+
+		return
+	}
+	if req.QuietHoursStart != nil && *req.QuietHoursStart != "" {
+		if !isValidLocalTime(*req.QuietHoursStart) {
+			apierror.Abort(c, invalidLocalTime("quiet_hours_start"))
+
+			return
+		}
+		if !isValidLocalTime(*req.QuietHoursEnd) {
+			apierror.Abort(c, invalidLocalTime("quiet_hours_end"))
+
+			return
+		}
+	}
+
+	preferences, err := h.preferences.Get(c.Request.Context(), user.ID)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve preferences", err))
+
+		return
+	}
+
+	timezoneChanged := req.Timezone != nil && *req.Timezone != preferences.Timezone
+	if req.Timezone != nil {
+		preferences.Timezone = *req.Timezone
+	}
+	if req.Locale != nil {
+		preferences.Locale = locale
+	}
+	if req.WeekStart != nil {
+		preferences.WeekStart = *req.WeekStart
+	}
+	if req.DefaultSessionType != nil {
+		preferences.DefaultSessionType = *req.DefaultSessionType
+	}
+	if req.DefaultDurationSeconds != nil {
+		preferences.DefaultDurationSeconds = *req.DefaultDurationSeconds
+	}
+	if req.Units != nil {
+		preferences.Units = *req.Units
+	}
+	if req.RemindersEnabled != nil {
+		preferences.RemindersEnabled = *req.RemindersEnabled
+	}
+	if req.StreakRemindersEnabled != nil {
+		preferences.StreakRemindersEnabled = *req.StreakRemindersEnabled
+	}
+	if req.QuietHoursStart != nil {
+		preferences.QuietHoursStart, preferences.QuietHoursEnd = nil, nil
+		if *req.QuietHoursStart != "" {
+			preferences.QuietHoursStart, preferences.QuietHoursEnd = req.QuietHoursStart, req.QuietHoursEnd
+		}
+	}
+	if req.ShareAnonymousUsage != nil {
+		preferences.ShareAnonymousUsage = *req.ShareAnonymousUsage
+	}
+
+	if err := h.preferences.Save(c.Request.Context(), preferences); err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to update preferences", err))
+
+		return
+	}
+
+	// Year reviews are cut at the user's midnight, so cached ones are stale in a new timezone.
+	// The preferences are already stored, so this must not fail the request.
+	if timezoneChanged {
+		if err := h.reviews.InvalidateYearReviews(c.Request.Context(), user.ID); err != nil {
+			slog.ErrorContext(c.Request.Context(), "failed to invalidate year reviews", slog.String("error", err.Error()))
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Preferences updated successfully",
+		"preferences": preferences,
+	})
+}
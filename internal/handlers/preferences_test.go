@@ -0,0 +1,132 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferences(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	setup := func() (*handlers.Handler, *repository.Repositories, *models.User) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		require.NoError(t, repos.Users.Create(ctx, user))
+
+		return handlers.New(repos), repos, user
+	}
+
+	serve := func(handle func(*gin.Context), user *models.User, method, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(method, "/api/user/preferences", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("user", *user)
+
+		handle(c)
+
+		return w
+	}
+
+	t.Run("return defaults when user never changed preferences", func(t *testing.T) {
+		h, _, user := setup()
+
+		w := serve(h.GetPreferences, user, "GET", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Preferences models.UserPreferences `json:"preferences"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "UTC", response.Preferences.Timezone)
+		assert.Equal(t, "monday", response.Preferences.WeekStart)
+		assert.Equal(t, 600, response.Preferences.DefaultDurationSeconds)
+		assert.False(t, response.Preferences.RemindersEnabled)
+	})
+
+	t.Run("change only the fields present", func(t *testing.T) {
+		h, repos, user := setup()
+
+		w := serve(h.UpdatePreferences, user, "PATCH", `{"timezone": "America/New_York", "locale": "en-gb", "reminders_enabled": true}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = serve(h.UpdatePreferences, user, "PATCH", `{"week_start": "sunday"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		stored, err := repos.Preferences.Get(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "America/New_York", stored.Timezone)
+		assert.Equal(t, "en-GB", stored.Locale)
+		assert.Equal(t, "sunday", stored.WeekStart)
+		assert.True(t, stored.RemindersEnabled)
+		assert.Equal(t, "mindfulness", stored.DefaultSessionType)
+	})
+
+	t.Run("drop cached year reviews when the timezone changes", func(t *testing.T) {
+		h, repos, user := setup()
+		require.NoError(t, repos.YearReviews.Save(ctx, &models.YearReview{UserID: user.ID, Year: 2024, Summary: "{}"}))
+
+		w := serve(h.UpdatePreferences, user, "PATCH", `{"timezone": "Asia/Tokyo"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		_, err := repos.YearReviews.Find(ctx, user.ID, 2024)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("set and clear quiet hours", func(t *testing.T) {
+		h, repos, user := setup()
+
+		w := serve(h.UpdatePreferences, user, "PATCH", `{"quiet_hours_start": "22:00", "quiet_hours_end": "07:00"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		stored, err := repos.Preferences.Get(ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.QuietHoursStart)
+		assert.Equal(t, "22:00", *stored.QuietHoursStart)
+		assert.Equal(t, "07:00", *stored.QuietHoursEnd)
+
+		w = serve(h.UpdatePreferences, user, "PATCH", `{"quiet_hours_start": "", "quiet_hours_end": ""}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		stored, err = repos.Preferences.Get(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.QuietHoursStart)
+		assert.Nil(t, stored.QuietHoursEnd)
+	})
+
+	t.Run("return bad request when a field is invalid", func(t *testing.T) {
+		h, repos, user := setup()
+
+		for _, body := range []string{
+			`{"timezone": "Mars/Olympus_Mons"}`,
+			`{"timezone": "Local"}`,
+			`{"locale": "not a locale"}`,
+			`{"week_start": "friday"}`,
+			`{"default_session_type": "napping"}`,
+			`{"default_duration_seconds": 5}`,
+			`{"units": "furlongs"}`,
+			`{"quiet_hours_start": "22:00"}`,
+			`{"quiet_hours_start": "22:00", "quiet_hours_end": ""}`,
+			`{"quiet_hours_start": "10pm", "quiet_hours_end": "07:00"}`,
+		} {
+			w := serve(h.UpdatePreferences, user, "PATCH", body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+
+		stored, err := repos.Preferences.Get(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.DefaultPreferences(user.ID), stored)
+	})
+}
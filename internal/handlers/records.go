@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+)
+
+// GetRecords returns personal records and lifetime milestones for the authenticated user
+func (h *Handler) GetRecords(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	records, err := h.analytics.GetRecords(c.Request.Context(), user.ID)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve records", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
@@ -0,0 +1,117 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRecords(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repos := memory.NewRepositories()
+	h := handlers.New(repos)
+
+	// Helper function to reset repositories before each test
+	resetRepos := func() {
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
+	}
+
+	t.Run("return personal records and milestones for user sessions", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+
+		sessions := []models.Session{
+			{UserID: testUser.ID, DurationSeconds: 600, SessionType: "mindfulness", CreatedAt: time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)},
+			{UserID: testUser.ID, DurationSeconds: 1200, SessionType: "breathing", CreatedAt: time.Date(2025, 3, 4, 8, 0, 0, 0, time.UTC)},
+			{UserID: testUser.ID, DurationSeconds: 900, SessionType: "metta", CreatedAt: time.Date(2025, 3, 4, 20, 0, 0, 0, time.UTC)},
+			{UserID: testUser.ID, DurationSeconds: 300, SessionType: "walking", CreatedAt: time.Date(2025, 3, 5, 8, 0, 0, 0, time.UTC)},
+			{UserID: testUser.ID, DurationSeconds: 1800, SessionType: "body_scan", CreatedAt: time.Date(2025, 4, 20, 8, 0, 0, 0, time.UTC)},
+		}
+		for _, session := range sessions {
+			repos.Sessions.Create(context.Background(), &session)
+		}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/records", nil)
+		c.Set("user", *testUser)
+
+		h.GetRecords(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var records services.Records
+		err := json.Unmarshal(w.Body.Bytes(), &records)
+		assert.NoError(t, err)
+
+		assert.NotNil(t, records.PersonalRecords.LongestSession)
+		assert.Equal(t, 1800, records.PersonalRecords.LongestSession.DurationSeconds)
+		assert.Equal(t, "2025-03-04", records.PersonalRecords.MostMinutesInDay.PeriodStart)
+		assert.Equal(t, 35, records.PersonalRecords.MostMinutesInDay.Minutes)
+		assert.Equal(t, "2025-03-03", records.PersonalRecords.MostMinutesInWeek.PeriodStart)
+		assert.Equal(t, 50, records.PersonalRecords.MostMinutesInWeek.Minutes)
+		assert.Equal(t, "2025-03-01", records.PersonalRecords.MostMinutesInMonth.PeriodStart)
+		assert.Equal(t, 50, records.PersonalRecords.MostMinutesInMonth.Minutes)
+		assert.Equal(t, 3, records.PersonalRecords.LongestStreak.Days)
+		assert.Equal(t, "2025-03-03", records.PersonalRecords.LongestStreak.StartDate)
+		assert.Equal(t, "2025-03-05", records.PersonalRecords.LongestStreak.EndDate)
+
+		assert.Equal(t, 5, records.Milestones.TotalSessions)
+		assert.Equal(t, 80, records.Milestones.TotalMinutes)
+		assert.True(t, records.Milestones.SessionMilestones[0].Achieved)
+		assert.Equal(t, "2025-03-03", records.Milestones.SessionMilestones[0].AchievedOn)
+		assert.False(t, records.Milestones.SessionMilestones[1].Achieved)
+		assert.True(t, records.Milestones.HourMilestones[0].Achieved)
+		assert.Equal(t, "2025-04-20", records.Milestones.HourMilestones[0].AchievedOn)
+	})
+
+	t.Run("return empty records when user has no sessions", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/records", nil)
+		c.Set("user", *testUser)
+
+		h.GetRecords(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var records services.Records
+		err := json.Unmarshal(w.Body.Bytes(), &records)
+		assert.NoError(t, err)
+
+		assert.Nil(t, records.PersonalRecords.LongestSession)
+		assert.Equal(t, 0, records.PersonalRecords.LongestStreak.Days)
+		assert.Equal(t, 0, records.Milestones.TotalSessions)
+		assert.False(t, records.Milestones.SessionMilestones[0].Achieved)
+	})
+
+	t.Run("return unauthorized when user not in context", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/records", nil)
+
+		h.GetRecords(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "User not found")
+	})
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+// maxRemindersPerUser keeps the scheduler's work per user bounded
+const maxRemindersPerUser = 10
+
+type CreateReminderRequest struct {
+	Days      []string `json:"days" binding:"required,min=1,max=7,unique,dive,oneof=sunday monday tuesday wednesday thursday friday saturday"`
+	LocalTime string   `json:"local_time" binding:"required"`
+	// Enabled defaults to true
+	Enabled *bool `json:"enabled"`
+}
+
+// UpdateReminderRequest changes only the fields that are present
+type UpdateReminderRequest struct {
+	Days      *[]string `json:"days" binding:"omitempty,min=1,max=7,unique,dive,oneof=sunday monday tuesday wednesday thursday friday saturday"`
+	LocalTime *string   `json:"local_time"`
+	Enabled   *bool     `json:"enabled"`
+}
+
+// GetReminders lists the authenticated user's reminders ordered by time of day
+func (h *Handler) GetReminders(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	reminders, err := h.reminders.ListForUser(c.Request.Context(), user.ID)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve reminders", err))
+
+		return
+	}
+
+	if reminders == nil {
+		reminders = []models.Reminder{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reminders": reminders,
+	})
+}
+
+// CreateReminder schedules a reminder for the authenticated user
+func (h *Handler) CreateReminder(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	var req CreateReminderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Validation(err))
+
+		return
+	}
+
+	if !isValidLocalTime(req.LocalTime) {
+		apierror.Abort(c, invalidLocalTime("local_time"))
+
+		return
+	}
+
+	existing, err := h.reminders.ListForUser(c.Request.Context(), user.ID)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve reminders", err))
+
+		return
+	}
+	if len(existing) >= maxRemindersPerUser {
+		apierror.Abort(c, apierror.New(http.StatusConflict, apierror.CodeReminderLimitReached,
+			"A user can have at most "+strconv.Itoa(maxRemindersPerUser)+" reminders"))
+
+		return
+	}
+
+	reminder := models.Reminder{
+		UserID:    user.ID,
+		Days:      sortDays(req.Days),
+		LocalTime: req.LocalTime,
+		Enabled:   req.Enabled == nil || *req.Enabled,
+	}
+
+	if err := h.reminders.Create(c.Request.Context(), &reminder); err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to create reminder", err))
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Reminder created successfully",
+		"reminder": reminder,
+	})
+}
+
+// UpdateReminder changes one of the authenticated user's reminders, leaving omitted fields as they are
+func (h *Handler) UpdateReminder(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	reminderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidReminderID, "Invalid reminder ID"))
+
+		return
+	}
+
+	var req UpdateReminderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Validation(err))
+
+		return
+	}
+
+	if req.LocalTime != nil && !isValidLocalTime(*req.LocalTime) {
+		apierror.Abort(c, invalidLocalTime("local_time"))
+
+		return
+	}
+
+	reminder, err := h.reminders.FindForUser(c.Request.Context(), user.ID, uint(reminderID))
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(http.StatusNotFound, apierror.CodeReminderNotFound, "Reminder not found"))
+
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve reminder", err))
+
+		return
+	}
+
+	if req.Days != nil {
+		reminder.Days = sortDays(*req.Days)
+	}
+	if req.LocalTime != nil {
+		reminder.LocalTime = *req.LocalTime
+	}
+	if req.Enabled != nil {
+		reminder.Enabled = *req.Enabled
+	}
+
+	if err := h.reminders.Update(c.Request.Context(), reminder); err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to update reminder", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Reminder updated successfully",
+		"reminder": reminder,
+	})
+}
+
+// DeleteReminder deletes one of the authenticated user's reminders
+func (h *Handler) DeleteReminder(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	reminderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidReminderID, "Invalid reminder ID"))
+
+		return
+	}
+
+	err = h.reminders.Delete(c.Request.Context(), user.ID, uint(reminderID))
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(http.StatusNotFound, apierror.CodeReminderNotFound, "Reminder not found"))
+
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to delete reminder", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reminder deleted successfully",
+	})
+}
+
+// isValidLocalTime reports whether s is a time of day written as HH:MM
+func isValidLocalTime(s string) bool {
+	_, err := time.Parse("15:04", s)
+
+	return err == nil && len(s) == len("15:04")
+}
+
+func invalidLocalTime(field string) *apierror.Error {
+	return apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "Invalid request data").
+		WithFields(apierror.FieldError{Field: field, Message: "must be a time of day such as 07:30"})
+}
+
+// sortDays orders weekday names from Sunday to Saturday so they read the same however they were sent
+func sortDays(days []string) []string {
+	sorted := slices.Clone(days)
+	slices.SortFunc(sorted, func(a, b string) int {
+		return slices.Index(models.Weekdays, a) - slices.Index(models.Weekdays, b)
+	})
+
+	return sorted
+}
@@ -0,0 +1,166 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	setup := func() (*handlers.Handler, *repository.Repositories, *models.User) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		require.NoError(t, repos.Users.Create(ctx, user))
+
+		return handlers.New(repos), repos, user
+	}
+
+	serve := func(handle func(*gin.Context), user *models.User, method, body string, params ...gin.Param) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(method, "/api/reminders", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = params
+		c.Set("user", *user)
+
+		handle(c)
+
+		return w
+	}
+
+	createReminder := func(t *testing.T, repos *repository.Repositories, userID string) *models.Reminder {
+		reminder := &models.Reminder{UserID: userID, Days: []string{"monday"}, LocalTime: "07:30", Enabled: true}
+		require.NoError(t, repos.Reminders.Create(ctx, reminder))
+
+		return reminder
+	}
+
+	t.Run("create an enabled reminder with days in week order", func(t *testing.T) {
+		h, repos, user := setup()
+
+		w := serve(h.CreateReminder, user, "POST", `{"days": ["friday", "monday", "sunday"], "local_time": "07:30"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response struct {
+			Reminder models.Reminder `json:"reminder"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []string{"sunday", "monday", "friday"}, response.Reminder.Days)
+		assert.True(t, response.Reminder.Enabled)
+
+		stored, err := repos.Reminders.ListForUser(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.Equal(t, "07:30", stored[0].LocalTime)
+	})
+
+	t.Run("return bad request when a field is invalid", func(t *testing.T) {
+		h, repos, user := setup()
+
+		for _, body := range []string{
+			`{"local_time": "07:30"}`,
+			`{"days": [], "local_time": "07:30"}`,
+			`{"days": ["someday"], "local_time": "07:30"}`,
+			`{"days": ["monday", "monday"], "local_time": "07:30"}`,
+			`{"days": ["monday"], "local_time": "7:30"}`,
+			`{"days": ["monday"], "local_time": "25:00"}`,
+		} {
+			w := serve(h.CreateReminder, user, "POST", body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+
+		stored, err := repos.Reminders.ListForUser(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+	})
+
+	t.Run("return conflict when user has too many reminders", func(t *testing.T) {
+		h, repos, user := setup()
+		for range 10 {
+			createReminder(t, repos, user.ID)
+		}
+
+		w := serve(h.CreateReminder, user, "POST", `{"days": ["monday"], "local_time": "07:30"}`)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "REMINDER_LIMIT_REACHED")
+	})
+
+	t.Run("list only the user's reminders", func(t *testing.T) {
+		h, repos, user := setup()
+		createReminder(t, repos, user.ID)
+		createReminder(t, repos, "other_user")
+
+		w := serve(h.GetReminders, user, "GET", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Reminders []models.Reminder `json:"reminders"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Reminders, 1)
+	})
+
+	t.Run("change only the fields present", func(t *testing.T) {
+		h, repos, user := setup()
+		reminder := createReminder(t, repos, user.ID)
+		id := gin.Param{Key: "id", Value: "1"}
+
+		w := serve(h.UpdateReminder, user, "PATCH", `{"local_time": "21:15", "enabled": false}`, id)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		stored, err := repos.Reminders.FindForUser(ctx, user.ID, reminder.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "21:15", stored.LocalTime)
+		assert.False(t, stored.Enabled)
+		assert.Equal(t, []string{"monday"}, stored.Days)
+	})
+
+	t.Run("return not found when reminder belongs to another user", func(t *testing.T) {
+		h, repos, user := setup()
+		createReminder(t, repos, "other_user")
+		id := gin.Param{Key: "id", Value: "1"}
+
+		w := serve(h.UpdateReminder, user, "PATCH", `{"enabled": false}`, id)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = serve(h.DeleteReminder, user, "DELETE", "", id)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("delete a reminder", func(t *testing.T) {
+		h, repos, user := setup()
+		createReminder(t, repos, user.ID)
+
+		w := serve(h.DeleteReminder, user, "DELETE", "", gin.Param{Key: "id", Value: "1"})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		stored, err := repos.Reminders.ListForUser(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+	})
+
+	t.Run("return bad request when reminder ID is invalid", func(t *testing.T) {
+		h, _, user := setup()
+
+		w := serve(h.DeleteReminder, user, "DELETE", "", gin.Param{Key: "id", Value: "abc"})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_REMINDER_ID")
+	})
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+)
+
+// GetYearReview returns the year-in-review summary for the authenticated user
+func (h *Handler) GetYearReview(c *gin.Context) {
+	user := auth.GetCurrentUser(c)
+	if user == nil {
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
+
+		return
+	}
+
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil || year < 1900 || year > time.Now().Year() {
+		apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidYear, "Invalid year"))
+
+		return
+	}
+
+	review, err := h.reviews.GetYearReview(c.Request.Context(), user.ID, year)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve year review", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
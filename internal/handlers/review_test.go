@@ -0,0 +1,177 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetYearReview(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repos := memory.NewRepositories()
+	h := handlers.New(repos)
+
+	// Helper function to reset repositories before each test
+	resetRepos := func() {
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
+	}
+
+	getReview := func(user *models.User, year string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/review/"+year, nil)
+		c.Params = gin.Params{{Key: "year", Value: year}}
+		if user != nil {
+			c.Set("user", *user)
+		}
+
+		h.GetYearReview(c)
+
+		return w
+	}
+
+	t.Run("return year summary and cache it for re-use", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+
+		sessions := []models.Session{
+			{UserID: testUser.ID, DurationSeconds: 600, SessionType: "breathing", CreatedAt: time.Date(2024, 2, 5, 8, 0, 0, 0, time.UTC)},
+			{UserID: testUser.ID, DurationSeconds: 1200, SessionType: "metta", CreatedAt: time.Date(2024, 2, 6, 8, 0, 0, 0, time.UTC)},
+			{UserID: testUser.ID, DurationSeconds: 900, SessionType: "breathing", CreatedAt: time.Date(2024, 2, 7, 8, 0, 0, 0, time.UTC)},
+			{UserID: testUser.ID, DurationSeconds: 300, SessionType: "walking", CreatedAt: time.Date(2024, 9, 2, 8, 0, 0, 0, time.UTC)},
+			{UserID: testUser.ID, DurationSeconds: 3000, SessionType: "mindfulness", CreatedAt: time.Date(2023, 12, 31, 8, 0, 0, 0, time.UTC)},
+		}
+		for _, session := range sessions {
+			repos.Sessions.Create(context.Background(), &session)
+		}
+
+		w := getReview(testUser, "2024")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var review services.YearReview
+		err := json.Unmarshal(w.Body.Bytes(), &review)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2024, review.Year)
+		assert.Equal(t, 4, review.TotalSessions)
+		assert.Equal(t, 50, review.TotalMinutes)
+		assert.Equal(t, 4, review.DaysMeditated)
+		assert.Equal(t, "February", review.BusiestMonth.Month)
+		assert.Equal(t, "Tuesday", review.BusiestWeekday.Weekday)
+		assert.Equal(t, "breathing", review.FavouriteSessionType.SessionType)
+		assert.Equal(t, 2, review.FavouriteSessionType.Sessions)
+		assert.Equal(t, 3, review.LongestStreak.Days)
+		assert.Equal(t, 1200, review.LongestSession.DurationSeconds)
+
+		_, err = repos.YearReviews.Find(context.Background(), testUser.ID, 2024)
+		assert.NoError(t, err)
+
+		// Cached summaries are served as generated
+		w = getReview(testUser, "2024")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var again services.YearReview
+		err = json.Unmarshal(w.Body.Bytes(), &again)
+		assert.NoError(t, err)
+		assert.True(t, review.GeneratedAt.Equal(again.GeneratedAt))
+	})
+
+	t.Run("count sessions towards the year in the user's timezone", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+		preferences := models.DefaultPreferences(testUser.ID)
+		preferences.Timezone = "America/Los_Angeles"
+		repos.Preferences.Save(context.Background(), preferences)
+
+		// New Year's Eve evening in Los Angeles, already 2025 in UTC
+		session := models.Session{UserID: testUser.ID, DurationSeconds: 600, SessionType: "breathing", CreatedAt: time.Date(2025, 1, 1, 4, 0, 0, 0, time.UTC)}
+		repos.Sessions.Create(context.Background(), &session)
+
+		var review services.YearReview
+		w := getReview(testUser, "2024")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &review))
+		assert.Equal(t, 1, review.TotalSessions)
+		assert.Equal(t, "December", review.BusiestMonth.Month)
+		assert.Equal(t, "Tuesday", review.BusiestWeekday.Weekday)
+
+		w = getReview(testUser, "2025")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &review))
+		assert.Equal(t, 0, review.TotalSessions)
+	})
+
+	t.Run("list the most used tags and the average mood change", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+
+		mood := func(value int) *int {
+			return &value
+		}
+		sessions := []models.Session{
+			{UserID: testUser.ID, DurationSeconds: 600, SessionType: "breathing", Tags: []string{"sleep", "evening"}, MoodBefore: mood(2), MoodAfter: mood(4), CreatedAt: time.Date(2024, 3, 1, 21, 0, 0, 0, time.UTC)},
+			{UserID: testUser.ID, DurationSeconds: 1200, SessionType: "breathing", Tags: []string{"sleep"}, MoodBefore: mood(3), MoodAfter: mood(4), CreatedAt: time.Date(2024, 3, 2, 21, 0, 0, 0, time.UTC)},
+			{UserID: testUser.ID, DurationSeconds: 300, SessionType: "walking", Tags: []string{"work"}, MoodAfter: mood(5), CreatedAt: time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)},
+		}
+		for _, session := range sessions {
+			repos.Sessions.Create(context.Background(), &session)
+		}
+
+		w := getReview(testUser, "2024")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var review services.YearReview
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &review))
+		assert.Equal(t, []services.TagSummary{
+			{Tag: "sleep", Sessions: 2, Minutes: 30},
+			{Tag: "evening", Sessions: 1, Minutes: 10},
+			{Tag: "work", Sessions: 1, Minutes: 5},
+		}, review.TopTags)
+		// Only sessions rated before and after count
+		assert.Equal(t, &services.MoodSummary{RatedSessions: 2, AverageBefore: 2.5, AverageAfter: 4, AverageChange: 1.5}, review.MoodImprovement)
+
+		w = getReview(testUser, "2023")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"top_tags":[]`)
+		assert.Contains(t, w.Body.String(), `"mood_improvement":null`)
+	})
+
+	t.Run("return bad request when year is invalid", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+
+		w := getReview(testUser, "invalid")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid year")
+
+		w = getReview(testUser, "2999")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("return unauthorized when user not in context", func(t *testing.T) {
+		w := getReview(nil, "2024")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "User not found")
+	})
+}
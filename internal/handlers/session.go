@@ -1,20 +1,30 @@
 package handlers
 
 import (
+	"errors"
+	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/constants"
-	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
 )
 
 type CreateSessionRequest struct {
 	DurationSeconds int    `json:"duration_seconds" binding:"required,min=1"`
 	SessionType     string `json:"session_type" binding:"required"`
 	Notes           string `json:"notes"`
+	// Tags are matched case-insensitively and stored in lowercase
+	Tags       []string `json:"tags" binding:"omitempty,max=10,dive,min=1,max=32"`
+	MoodBefore *int     `json:"mood_before" binding:"omitempty,min=1,max=5"`
+	MoodAfter  *int     `json:"mood_after" binding:"omitempty,min=1,max=5"`
 }
 
 type GetSessionsResponse struct {
@@ -37,25 +47,39 @@ func isValidSessionType(sessionType string) bool {
 	return validSessionTypes[sessionType]
 }
 
+// normalizeTags trims and lowercases tags, dropping blanks and repeats so "Sleep" and "sleep " count as one
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized
+}
+
 // CreateSession creates a new meditation session for the authenticated user
-func CreateSession(c *gin.Context) {
+func (h *Handler) CreateSession(c *gin.Context) {
 	user := auth.GetCurrentUser(c)
 	if user == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
 
 		return
 	}
 
 	var req CreateSessionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data", "details": err.Error()})
+		apierror.Abort(c, apierror.Validation(err))
 
 		return
 	}
 
 	// Validate session type
 	if !isValidSessionType(req.SessionType) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session type"})
+		apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidSessionType, "Invalid session type").
+			WithFields(apierror.FieldError{Field: "session_type", Message: "must be one of " + strings.Join(constants.SessionTypes, ", ")}))
 
 		return
 	}
@@ -65,25 +89,44 @@ func CreateSession(c *gin.Context) {
 		DurationSeconds: req.DurationSeconds,
 		SessionType:     req.SessionType,
 		Notes:           req.Notes,
+		Tags:            normalizeTags(req.Tags),
+		MoodBefore:      req.MoodBefore,
+		MoodAfter:       req.MoodAfter,
 	}
 
-	if err := database.DB.Create(&session).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create session", "details": err.Error()})
+	if err := h.sessions.Create(c.Request.Context(), &session); err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to create session", err))
 
 		return
 	}
+	metrics.SessionCreated(session.SessionType)
+
+	// Follow-up bookkeeping must not fail the request, the session is already stored
+	if err := h.reviews.InvalidateYearReview(c.Request.Context(), user.ID, session.CreatedAt); err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to invalidate year review", slog.String("error", err.Error()))
+	}
+
+	newAchievements, err := h.achievements.EvaluateAchievements(c.Request.Context(), user.ID, &session)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to evaluate achievements", slog.String("error", err.Error()))
+	}
+
+	if newAchievements == nil {
+		newAchievements = []models.UserAchievement{}
+	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Session created successfully",
-		"session": session,
+		"message":          "Session created successfully",
+		"session":          session,
+		"new_achievements": newAchievements,
 	})
 }
 
 // GetSessions retrieves user's meditation sessions with cursor-based pagination
-func GetSessions(c *gin.Context) {
+func (h *Handler) GetSessions(c *gin.Context) {
 	user := auth.GetCurrentUser(c)
 	if user == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
 
 		return
 	}
@@ -102,16 +145,10 @@ func GetSessions(c *gin.Context) {
 		}
 	}
 
-	// Build query
-	query := database.DB.Where("user_id = ?", user.ID)
-	
-	if lastID > 0 {
-		query = query.Where("id < ?", lastID)
-	}
-
-	var sessions []models.Session
-	if err := query.Order("id DESC").Limit(limit + 1).Find(&sessions).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve sessions", "details": err.Error()})
+	// Fetch one extra session to detect whether another page exists
+	sessions, err := h.sessions.ListBefore(c.Request.Context(), user.ID, lastID, limit+1)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve sessions", err))
 
 		return
 	}
@@ -137,10 +174,10 @@ func GetSessions(c *gin.Context) {
 }
 
 // DeleteSession soft deletes a meditation session
-func DeleteSession(c *gin.Context) {
+func (h *Handler) DeleteSession(c *gin.Context) {
 	user := auth.GetCurrentUser(c)
 	if user == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
 
 		return
 	}
@@ -148,27 +185,36 @@ func DeleteSession(c *gin.Context) {
 	sessionIDStr := c.Param("id")
 	sessionID, err := strconv.ParseUint(sessionIDStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		apierror.Abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidSessionID, "Invalid session ID"))
 
 		return
 	}
 
 	// Check if session exists and belongs to user
-	var session models.Session
-	if err := database.DB.Where("id = ? AND user_id = ?", uint(sessionID), user.ID).First(&session).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+	session, err := h.sessions.FindForUser(c.Request.Context(), user.ID, uint(sessionID))
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(http.StatusNotFound, apierror.CodeSessionNotFound, "Session not found"))
+
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to retrieve session", err))
 
 		return
 	}
 
 	// Soft delete the session
-	if err := database.DB.Delete(&session).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete session", "details": err.Error()})
+	if err := h.sessions.Delete(c.Request.Context(), session); err != nil {
+		apierror.Abort(c, apierror.Internal("Failed to delete session", err))
 
 		return
 	}
 
+	if err := h.reviews.InvalidateYearReview(c.Request.Context(), user.ID, session.CreatedAt); err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to invalidate year review", slog.String("error", err.Error()))
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Session deleted successfully",
 	})
-}
\ No newline at end of file
+}
@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -10,33 +11,32 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/constants"
-	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreateSession(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	db := testutils.SetupTestDB(t)
-	database.DB = db
-	defer testutils.CleanupTestDB(t, db)
+	repos := memory.NewRepositories()
+	h := handlers.New(repos)
 
 	router := gin.New()
-	router.POST("/sessions", handlers.CreateSession)
+	router.POST("/sessions", h.CreateSession)
 
-	// Helper function to clean database before each test
-	cleanDB := func() {
-		testutils.TruncateTable(db, "sessions")
-		testutils.TruncateTable(db, "users")
+	// Helper function to reset repositories before each test
+	resetRepos := func() {
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
 	}
 
 	t.Run("successfully create session when valid data provided", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		testUser := testutils.CreateTestUser("test_clerk_id")
-		db.Create(testUser)
+		repos.Users.Create(context.Background(), testUser)
 
 		requestBody := map[string]interface{}{
 			"duration_seconds": 600,
@@ -52,7 +52,7 @@ func TestCreateSession(t *testing.T) {
 		c.Request = req
 		c.Set("user", *testUser)
 
-		handlers.CreateSession(c)
+		h.CreateSession(c)
 
 		assert.Equal(t, http.StatusCreated, w.Code)
 		assert.Contains(t, w.Body.String(), "Session created successfully")
@@ -60,6 +60,57 @@ func TestCreateSession(t *testing.T) {
 		assert.Contains(t, w.Body.String(), requestBody["session_type"].(string))
 	})
 
+	t.Run("store tags in lowercase without repeats along with mood ratings", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+
+		body := `{"duration_seconds": 600, "session_type": "breathing", "tags": ["Sleep", " sleep ", "evening"], "mood_before": 2, "mood_after": 4}`
+		req := httptest.NewRequest("POST", "/sessions", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Set("user", *testUser)
+
+		h.CreateSession(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		sessions, err := repos.Sessions.Recent(context.Background(), testUser.ID, 1)
+		assert.NoError(t, err)
+		if assert.Len(t, sessions, 1) {
+			assert.Equal(t, []string{"sleep", "evening"}, sessions[0].Tags)
+			assert.Equal(t, 2, *sessions[0].MoodBefore)
+			assert.Equal(t, 4, *sessions[0].MoodAfter)
+		}
+	})
+
+	t.Run("return bad request when mood or tags are out of range", func(t *testing.T) {
+		resetRepos()
+
+		testUser := testutils.CreateTestUser("test_clerk_id")
+		repos.Users.Create(context.Background(), testUser)
+
+		for _, body := range []string{
+			`{"duration_seconds": 600, "session_type": "breathing", "mood_before": 0}`,
+			`{"duration_seconds": 600, "session_type": "breathing", "mood_after": 6}`,
+			`{"duration_seconds": 600, "session_type": "breathing", "tags": [""]}`,
+			`{"duration_seconds": 600, "session_type": "breathing", "tags": ["a","b","c","d","e","f","g","h","i","j","k"]}`,
+		} {
+			req := httptest.NewRequest("POST", "/sessions", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("user", *testUser)
+
+			h.CreateSession(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+
 	t.Run("return unauthorized when user not in context", func(t *testing.T) {
 		requestBody := map[string]interface{}{
 			"duration_seconds": 600,
@@ -73,17 +124,17 @@ func TestCreateSession(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		handlers.CreateSession(c)
+		h.CreateSession(c)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.Contains(t, w.Body.String(), "User not found")
 	})
 
 	t.Run("return bad request when invalid session type provided", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		testUser := testutils.CreateTestUser("test_clerk_id")
-		db.Create(testUser)
+		repos.Users.Create(context.Background(), testUser)
 
 		requestBody := map[string]interface{}{
 			"duration_seconds": 600,
@@ -99,17 +150,18 @@ func TestCreateSession(t *testing.T) {
 		c.Request = req
 		c.Set("user", *testUser)
 
-		handlers.CreateSession(c)
+		h.CreateSession(c)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		assert.Contains(t, w.Body.String(), "Invalid session type")
+		assert.Contains(t, w.Body.String(), `"code":"INVALID_SESSION_TYPE"`)
 	})
 
 	t.Run("return bad request when duration is missing", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		testUser := testutils.CreateTestUser("test_clerk_id")
-		db.Create(testUser)
+		repos.Users.Create(context.Background(), testUser)
 
 		requestBody := map[string]interface{}{
 			"session_type": constants.SessionTypeMindfulness,
@@ -123,17 +175,18 @@ func TestCreateSession(t *testing.T) {
 		c.Request = req
 		c.Set("user", *testUser)
 
-		handlers.CreateSession(c)
+		h.CreateSession(c)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		assert.Contains(t, w.Body.String(), "Invalid request data")
+		assert.Contains(t, w.Body.String(), `{"field":"duration_seconds","message":"is required"}`)
 	})
 
 	t.Run("return bad request when duration is zero or negative", func(t *testing.T) {
-		cleanDB()
+		resetRepos()
 
 		testUser := testutils.CreateTestUser("test_clerk_id")
-		db.Create(testUser)
+		repos.Users.Create(context.Background(), testUser)
 
 		requestBody := map[string]interface{}{
 			"duration_seconds": 0,
@@ -148,7 +201,7 @@ func TestCreateSession(t *testing.T) {
 		c.Request = req
 		c.Set("user", *testUser)
 
-		handlers.CreateSession(c)
+		h.CreateSession(c)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		assert.Contains(t, w.Body.String(), "Invalid request data")
@@ -157,20 +210,19 @@ func TestCreateSession(t *testing.T) {
 
 func TestGetSessions(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	db := testutils.SetupTestDB(t)
-	database.DB = db
-	defer testutils.CleanupTestDB(t, db)
+	repos := memory.NewRepositories()
+	h := handlers.New(repos)
 
 	router := gin.New()
-	router.GET("/sessions", handlers.GetSessions)
+	router.GET("/sessions", h.GetSessions)
 
-	// Helper function to clean database and create test data
+	// Helper function to reset repositories and create test data
 	setupTestData := func() (*models.User, []models.Session) {
-		testutils.TruncateTable(db, "sessions")
-		testutils.TruncateTable(db, "users")
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
 
 		testUser := testutils.CreateTestUser("test_clerk_id")
-		db.Create(testUser)
+		repos.Users.Create(context.Background(), testUser)
 
 		// Create test sessions
 		sessions := []models.Session{
@@ -180,7 +232,7 @@ func TestGetSessions(t *testing.T) {
 		}
 
 		for i := range sessions {
-			db.Create(&sessions[i])
+			repos.Sessions.Create(context.Background(), &sessions[i])
 		}
 
 		return testUser, sessions
@@ -195,7 +247,7 @@ func TestGetSessions(t *testing.T) {
 		c.Request = req
 		c.Set("user", *testUser)
 
-		handlers.GetSessions(c)
+		h.GetSessions(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -213,7 +265,7 @@ func TestGetSessions(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		handlers.GetSessions(c)
+		h.GetSessions(c)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.Contains(t, w.Body.String(), "User not found")
@@ -229,7 +281,7 @@ func TestGetSessions(t *testing.T) {
 		c.Request = req
 		c.Set("user", *testUser)
 
-		handlers.GetSessions(c)
+		h.GetSessions(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -244,20 +296,19 @@ func TestGetSessions(t *testing.T) {
 
 func TestDeleteSession(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	db := testutils.SetupTestDB(t)
-	database.DB = db
-	defer testutils.CleanupTestDB(t, db)
+	repos := memory.NewRepositories()
+	h := handlers.New(repos)
 
 	router := gin.New()
-	router.DELETE("/sessions/:id", handlers.DeleteSession)
+	router.DELETE("/sessions/:id", h.DeleteSession)
 
-	// Helper function to clean database and create test data
+	// Helper function to reset repositories and create test data
 	setupTestData := func() (*models.User, models.Session) {
-		testutils.TruncateTable(db, "sessions")
-		testutils.TruncateTable(db, "users")
+		repos = memory.NewRepositories()
+		h = handlers.New(repos)
 
 		testUser := testutils.CreateTestUser("test_clerk_id")
-		db.Create(testUser)
+		repos.Users.Create(context.Background(), testUser)
 
 		session := models.Session{
 			UserID:          testUser.ID,
@@ -265,7 +316,7 @@ func TestDeleteSession(t *testing.T) {
 			SessionType:     constants.SessionTypeMindfulness,
 			Notes:           "Test session",
 		}
-		db.Create(&session)
+		repos.Sessions.Create(context.Background(), &session)
 
 		return testUser, session
 	}
@@ -280,7 +331,7 @@ func TestDeleteSession(t *testing.T) {
 		c.Set("user", *testUser)
 		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(session.ID))}}
 
-		handlers.DeleteSession(c)
+		h.DeleteSession(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Contains(t, w.Body.String(), "Session deleted successfully")
@@ -293,7 +344,7 @@ func TestDeleteSession(t *testing.T) {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
-		handlers.DeleteSession(c)
+		h.DeleteSession(c)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.Contains(t, w.Body.String(), "User not found")
@@ -309,7 +360,7 @@ func TestDeleteSession(t *testing.T) {
 		c.Set("user", *testUser)
 		c.Params = gin.Params{{Key: "id", Value: "invalid"}}
 
-		handlers.DeleteSession(c)
+		h.DeleteSession(c)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		assert.Contains(t, w.Body.String(), "Invalid session ID")
@@ -317,7 +368,7 @@ func TestDeleteSession(t *testing.T) {
 
 	t.Run("return not found when session does not exist or belongs to different user", func(t *testing.T) {
 		testUser := testutils.CreateTestUser("test_clerk_id")
-		db.Create(testUser)
+		repos.Users.Create(context.Background(), testUser)
 
 		req := httptest.NewRequest("DELETE", "/sessions/999", nil)
 		w := httptest.NewRecorder()
@@ -326,9 +377,10 @@ func TestDeleteSession(t *testing.T) {
 		c.Set("user", *testUser)
 		c.Params = gin.Params{{Key: "id", Value: "999"}}
 
-		handlers.DeleteSession(c)
+		h.DeleteSession(c)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 		assert.Contains(t, w.Body.String(), "Session not found")
+		assert.Contains(t, w.Body.String(), `"code":"SESSION_NOT_FOUND"`)
 	})
-}
\ No newline at end of file
+}
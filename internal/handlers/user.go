@@ -4,13 +4,15 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
 )
 
-func GetUserProfile(c *gin.Context) {
+// GetUserProfile returns the authenticated user's profile
+func (h *Handler) GetUserProfile(c *gin.Context) {
 	user := auth.GetCurrentUser(c)
 	if user == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		apierror.Abort(c, apierror.New(http.StatusUnauthorized, apierror.CodeUserNotFound, "User not found"))
 
 		return
 	}
@@ -21,8 +23,11 @@ func GetUserProfile(c *gin.Context) {
 			"email":      user.Email,
 			"first_name": user.FirstName,
 			"last_name":  user.LastName,
+			"role":       user.Role,
 			"created_at": user.CreatedAt,
 			"updated_at": user.UpdatedAt,
+			// When the account will be purged if its deletion isn't cancelled first
+			"delete_after": h.accounts.DeleteAfter(user),
 		},
 	})
 }
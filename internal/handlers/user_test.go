@@ -7,15 +7,17 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGetUserProfile(t *testing.T) {
 	gin.SetMode(gin.TestMode)
+	h := handlers.New(memory.NewRepositories())
 
 	router := gin.New()
-	router.GET("/user/profile", handlers.GetUserProfile)
+	router.GET("/user/profile", h.GetUserProfile)
 
 	t.Run("return user profile when user exists in context", func(t *testing.T) {
 		testUser := testutils.CreateTestUser("test_clerk_id")
@@ -28,7 +30,7 @@ func TestGetUserProfile(t *testing.T) {
 		c.Request = req
 		c.Set("user", *testUser)
 
-		handlers.GetUserProfile(c)
+		h.GetUserProfile(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Contains(t, w.Body.String(), testUser.ID)
@@ -45,7 +47,7 @@ func TestGetUserProfile(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		handlers.GetUserProfile(c)
+		h.GetUserProfile(c)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.Contains(t, w.Body.String(), "User not found")
@@ -60,7 +62,7 @@ func TestGetUserProfile(t *testing.T) {
 		c.Request = req
 		c.Set("user", "invalid_user_type")
 
-		handlers.GetUserProfile(c)
+		h.GetUserProfile(c)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.Contains(t, w.Body.String(), "User not found")
@@ -79,7 +81,7 @@ func TestGetUserProfile(t *testing.T) {
 		c.Request = req
 		c.Set("user", *testUser)
 
-		handlers.GetUserProfile(c)
+		h.GetUserProfile(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Contains(t, w.Body.String(), testUser.ID)
@@ -100,7 +102,7 @@ func TestGetUserProfile(t *testing.T) {
 		c.Request = req
 		c.Set("user", *testUser)
 
-		handlers.GetUserProfile(c)
+		h.GetUserProfile(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Contains(t, w.Body.String(), testUser.ID)
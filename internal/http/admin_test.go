@@ -0,0 +1,127 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/audit"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/http"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+)
+
+// subjectProvider accepts any token and treats it as the user's subject
+type subjectProvider struct{}
+
+func (subjectProvider) Name() string {
+	return "test"
+}
+
+func (subjectProvider) Verify(_ context.Context, token string) (auth.Identity, error) {
+	return auth.Identity{Subject: token}, nil
+}
+
+func (subjectProvider) Provision(context.Context, repository.UserRepository, auth.Identity) (*models.User, error) {
+	return nil, auth.ErrUserNotProvisioned
+}
+
+func (subjectProvider) WebhookHandler(repository.UserRepository, repository.WebhookEventRepository) gin.HandlerFunc {
+	return nil
+}
+
+func TestAdminRoutes(t *testing.T) {
+	repos := memory.NewRepositories()
+	admin := testutils.CreateTestUser("admin_clerk")
+	admin.Role = models.RoleAdmin
+	require.NoError(t, repos.Users.Create(context.Background(), admin))
+	user := testutils.CreateTestUser("user_clerk")
+	require.NoError(t, repos.Users.Create(context.Background(), user))
+
+	pat, hash, prefix, err := auth.GenerateAccessToken()
+	require.NoError(t, err)
+	require.NoError(t, repos.AccessTokens.Create(context.Background(), &models.AccessToken{
+		UserID: admin.ID, Name: "Script", Prefix: prefix, TokenHash: hash, Scopes: auth.Scopes,
+	}))
+
+	server := http.NewServer(&config.Config{Server: config.ServerConfig{GinMode: gin.TestMode}}, repos, func(ctx context.Context) error {
+		return nil
+	}, http.WithAuthProvider(subjectProvider{}))
+
+	serve := func(token, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("serve admin routes to admins", func(t *testing.T) {
+		w := serve("admin_clerk", "/api/admin/users?clerk_user_id=user_clerk")
+
+		assert.Equal(t, nethttp.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "user_clerk")
+	})
+
+	t.Run("return forbidden to other users", func(t *testing.T) {
+		w := serve("user_clerk", "/api/admin/stats")
+
+		assert.Equal(t, nethttp.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "INSUFFICIENT_ROLE")
+	})
+
+	t.Run("return forbidden to personal access tokens", func(t *testing.T) {
+		w := serve(pat, "/api/admin/stats")
+
+		assert.Equal(t, nethttp.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "INSUFFICIENT_SCOPE")
+	})
+
+	t.Run("audit admin requests including denied ones", func(t *testing.T) {
+		var logs bytes.Buffer
+		previous := slog.Default()
+		slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+		defer slog.SetDefault(previous)
+
+		serve("admin_clerk", "/api/admin/users/"+admin.ID)
+		serve("user_clerk", "/api/admin/stats")
+
+		assert.Contains(t, logs.String(), `"msg":"admin request","actor_id":"`+admin.ID+`","method":"GET","route":"/api/admin/users/:id","params":{"id":"`+admin.ID+`"}`)
+		assert.Regexp(t, `"msg":"admin request".*"route":"/api/admin/stats".*"status":403`, logs.String())
+	})
+
+	t.Run("store admin reads in the audit log", func(t *testing.T) {
+		serve("admin_clerk", "/api/admin/users/"+user.ID+"/sessions")
+		serve("admin_clerk", "/api/admin/users?clerk_user_id=user_clerk")
+		serve("user_clerk", "/api/admin/users/"+user.ID)
+
+		events, err := repos.AuditEvents.ListForUser(context.Background(), user.ID, 0, 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		assert.Equal(t, "admin.sessions_viewed", events[0].Action)
+		assert.Equal(t, audit.ActorAdmin, events[0].ActorType)
+		assert.Equal(t, admin.ID, events[0].ActorID)
+		assert.Equal(t, "user", events[0].TargetType)
+		assert.Equal(t, user.ID, events[0].TargetID)
+		for _, event := range events {
+			assert.NotEqual(t, user.ID, event.ActorID, "denied requests aren't stored")
+		}
+
+		events, err = repos.AuditEvents.ListForUser(context.Background(), admin.ID, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, "admin.users_searched", events[0].Action)
+	})
+}
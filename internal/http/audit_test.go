@@ -0,0 +1,87 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/http"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+)
+
+func TestAuditLog(t *testing.T) {
+	repos := memory.NewRepositories()
+	admin := testutils.CreateTestUser("admin_clerk")
+	admin.Role = models.RoleAdmin
+	require.NoError(t, repos.Users.Create(context.Background(), admin))
+	user := testutils.CreateTestUser("user_clerk")
+	require.NoError(t, repos.Users.Create(context.Background(), user))
+
+	server := http.NewServer(&config.Config{Server: config.ServerConfig{GinMode: gin.TestMode}}, repos, func(ctx context.Context) error {
+		return nil
+	}, http.WithAuthProvider(subjectProvider{}))
+
+	serve := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", "req-"+method)
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+
+		return w
+	}
+
+	history := func() []models.AuditEvent {
+		w := serve("user_clerk", "GET", "/api/v1/audit", "")
+		require.Equal(t, nethttp.StatusOK, w.Code)
+
+		var page handlers.GetAuditEventsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+
+		return page.AuditEvents
+	}
+
+	t.Run("attribute changes to the user with request ID and IP", func(t *testing.T) {
+		w := serve("user_clerk", "POST", "/api/v1/sessions", `{"duration_seconds": 600, "session_type": "breathing"}`)
+		require.Equal(t, nethttp.StatusCreated, w.Code)
+
+		events := history()
+
+		require.Len(t, events, 1)
+		assert.Equal(t, "session.created", events[0].Action)
+		assert.Equal(t, "user", events[0].ActorType)
+		assert.Equal(t, user.ID, events[0].ActorID)
+		assert.Equal(t, "req-POST", events[0].RequestID)
+		assert.Equal(t, "192.0.2.1", events[0].IP)
+	})
+
+	t.Run("attribute admin changes to an anonymous admin", func(t *testing.T) {
+		session := testutils.CreateTestSession(user.ID)
+		require.NoError(t, repos.Sessions.Create(context.Background(), session))
+		require.NoError(t, repos.Sessions.Delete(context.Background(), session))
+
+		w := serve("admin_clerk", "POST", "/api/admin/users/"+user.ID+"/sessions/"+strconv.Itoa(int(session.ID))+"/restore", "")
+		require.Equal(t, nethttp.StatusOK, w.Code)
+
+		events := history()
+
+		require.NotEmpty(t, events)
+		assert.Equal(t, "session.restored", events[0].Action)
+		assert.Equal(t, "admin", events[0].ActorType)
+		assert.Empty(t, events[0].ActorID)
+		assert.Empty(t, events[0].IP)
+	})
+}
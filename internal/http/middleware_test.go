@@ -0,0 +1,162 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/http"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+)
+
+// rejectingProvider refuses every token, counting how often it was asked
+type rejectingProvider struct {
+	subjectProvider
+
+	verified *int
+}
+
+func (p rejectingProvider) Verify(context.Context, string) (auth.Identity, error) {
+	*p.verified++
+
+	return auth.Identity{}, errors.New("invalid token")
+}
+
+func TestMiddleware(t *testing.T) {
+	newServer := func(cfg *config.Config) *http.Server {
+		cfg.Server.GinMode = gin.TestMode
+
+		return http.NewServer(cfg, memory.NewRepositories(), func(ctx context.Context) error {
+			return nil
+		})
+	}
+	corsConfig := config.CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{"GET", "POST", "DELETE"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	}
+
+	t.Run("answer preflight requests from allowed origins", func(t *testing.T) {
+		server := newServer(&config.Config{CORS: corsConfig})
+		req := httptest.NewRequest("OPTIONS", "/api/v1/sessions", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		req.Header.Set("Access-Control-Request-Headers", "Authorization, Content-Type")
+
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+
+		assert.Equal(t, nethttp.StatusNoContent, w.Code)
+		assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "POST")
+		assert.Equal(t, "3600", w.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("reject requests from other origins", func(t *testing.T) {
+		server := newServer(&config.Config{CORS: corsConfig})
+		req := httptest.NewRequest("GET", "/api/v1/ping", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+
+		assert.Equal(t, nethttp.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("expose request id to allowed origins", func(t *testing.T) {
+		server := newServer(&config.Config{CORS: corsConfig})
+		req := httptest.NewRequest("GET", "/api/v1/ping", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+
+		assert.Equal(t, nethttp.StatusOK, w.Code)
+		assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), "X-Request-Id")
+	})
+
+	t.Run("set security headers on every response", func(t *testing.T) {
+		server := newServer(&config.Config{Server: config.ServerConfig{HSTSMaxAge: 365 * 24 * time.Hour}})
+
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/missing", nil))
+
+		assert.Equal(t, nethttp.StatusNotFound, w.Code)
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "max-age=31536000; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("omit hsts when max age is zero", func(t *testing.T) {
+		server := newServer(&config.Config{})
+
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/ping", nil))
+
+		assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	})
+
+	// Rate limiting is keyed by client IP on public routes, so it shows which address was used
+	pingFrom := func(server *http.Server, remoteAddr, forwardedFor string) int {
+		req := httptest.NewRequest("GET", "/api/v1/ping", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+
+		return w.Code
+	}
+	oneRequestPerClient := config.RateLimitConfig{Public: config.RateLimit{Requests: 1, Period: time.Minute}}
+
+	t.Run("ignore forwarded for headers from untrusted clients", func(t *testing.T) {
+		server := newServer(&config.Config{RateLimit: oneRequestPerClient})
+
+		assert.Equal(t, nethttp.StatusOK, pingFrom(server, "203.0.113.5:4000", "198.51.100.1"))
+		assert.Equal(t, nethttp.StatusTooManyRequests, pingFrom(server, "203.0.113.5:4000", "198.51.100.2"))
+	})
+
+	t.Run("use forwarded for headers from trusted proxies", func(t *testing.T) {
+		server := newServer(&config.Config{
+			Server:    config.ServerConfig{TrustedProxies: []string{"10.0.0.0/8"}},
+			RateLimit: oneRequestPerClient,
+		})
+
+		assert.Equal(t, nethttp.StatusOK, pingFrom(server, "10.1.2.3:4000", "198.51.100.1"))
+		assert.Equal(t, nethttp.StatusOK, pingFrom(server, "10.1.2.3:4000", "198.51.100.2"))
+	})
+
+	t.Run("limit clients by IP before verifying their token", func(t *testing.T) {
+		verified := 0
+		server := http.NewServer(&config.Config{
+			Server:    config.ServerConfig{GinMode: gin.TestMode},
+			RateLimit: config.RateLimitConfig{Auth: config.RateLimit{Requests: 2, Period: time.Minute}},
+		}, memory.NewRepositories(), func(ctx context.Context) error {
+			return nil
+		}, http.WithAuthProvider(rejectingProvider{verified: &verified}))
+
+		codes := make([]int, 0, 3)
+		for range 3 {
+			req := httptest.NewRequest("GET", "/api/v1/sessions", nil)
+			req.Header.Set("Authorization", "Bearer invalid")
+			w := httptest.NewRecorder()
+			server.Handler().ServeHTTP(w, req)
+			codes = append(codes, w.Code)
+		}
+
+		assert.Equal(t, []int{nethttp.StatusUnauthorized, nethttp.StatusUnauthorized, nethttp.StatusTooManyRequests}, codes)
+		assert.Equal(t, 2, verified)
+	})
+}
@@ -0,0 +1,211 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindful-minutes/mindful-minutes-api/api"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/http"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+)
+
+// TestOpenAPIContract sends real requests through the server and fails when a request or
+// response doesn't match api/openapi.yaml, so handler changes can't drift from the spec.
+func TestOpenAPIContract(t *testing.T) {
+	doc, err := api.Load()
+	require.NoError(t, err)
+	specRouter, err := legacy.NewRouter(doc)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://clerk.test/verify", httpmock.NewStringResponder(200, `{"sub": "clerk_contract"}`))
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{GinMode: gin.TestMode},
+		Auth: config.AuthConfig{
+			ClerkSecretKey: "test_secret_key",
+			ClerkVerifyURL: "https://clerk.test/verify",
+		},
+	}
+	repos := memory.NewRepositories()
+	user := testutils.CreateTestUser("clerk_contract")
+	require.NoError(t, repos.Users.Create(context.Background(), user))
+	server := http.NewServer(cfg, repos, func(ctx context.Context) error {
+		return nil
+	})
+
+	session := &models.Session{UserID: user.ID, DurationSeconds: 900, SessionType: "metta", Notes: "evening"}
+	require.NoError(t, repos.Sessions.Create(context.Background(), session))
+
+	pat, hash, prefix, err := auth.GenerateAccessToken()
+	require.NoError(t, err)
+	require.NoError(t, repos.AccessTokens.Create(context.Background(), &models.AccessToken{
+		UserID: user.ID, Name: "Shortcut", Prefix: prefix, TokenHash: hash, Scopes: []string{auth.ScopeStatsRead},
+	}))
+	withPAT := map[string]string{"Authorization": "Bearer " + pat}
+
+	webhookPayload, _ := json.Marshal(auth.ClerkWebhookEvent{
+		Type: "user.created",
+		Data: auth.ClerkUser{ID: "clerk_webhook", EmailAddresses: []auth.ClerkEmailAddress{{EmailAddress: "new@example.com", Primary: true}}},
+	})
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		headers map[string]string
+		status  int
+	}{
+		{name: "ping", method: "GET", path: "/ping", status: nethttp.StatusOK},
+		{name: "profile", method: "GET", path: "/user/profile", status: nethttp.StatusOK},
+		{name: "profile without token", method: "GET", path: "/user/profile", headers: map[string]string{"Authorization": ""}, status: nethttp.StatusUnauthorized},
+		{name: "default preferences", method: "GET", path: "/user/preferences", status: nethttp.StatusOK},
+		{name: "update preferences", method: "PATCH", path: "/user/preferences", body: `{"timezone": "Europe/Berlin", "week_start": "sunday", "reminders_enabled": true}`, status: nethttp.StatusOK},
+		{name: "update quiet hours", method: "PATCH", path: "/user/preferences", body: `{"quiet_hours_start": "22:00", "quiet_hours_end": "07:00"}`, status: nethttp.StatusOK},
+		{name: "update preferences with unknown timezone", method: "PATCH", path: "/user/preferences", body: `{"timezone": "Mars/Olympus_Mons"}`, status: nethttp.StatusBadRequest},
+		{name: "update preferences with access token", method: "PATCH", path: "/user/preferences", body: `{"units": "imperial"}`, headers: withPAT, status: nethttp.StatusForbidden},
+		{name: "create session", method: "POST", path: "/sessions", body: `{"duration_seconds": 600, "session_type": "breathing", "notes": "calm"}`, status: nethttp.StatusCreated},
+		{name: "create invalid session", method: "POST", path: "/sessions", body: `{"duration_seconds": 0, "session_type": "breathing"}`, status: nethttp.StatusBadRequest},
+		{name: "list sessions", method: "GET", path: "/sessions?limit=1", status: nethttp.StatusOK},
+		{name: "dashboard", method: "GET", path: "/dashboard?sessions=3", status: nethttp.StatusOK},
+		{name: "records", method: "GET", path: "/records", status: nethttp.StatusOK},
+		{name: "achievements", method: "GET", path: "/achievements", status: nethttp.StatusOK},
+		{name: "year review", method: "GET", path: fmt.Sprintf("/review/%d", session.CreatedAt.Year()), status: nethttp.StatusOK},
+		{name: "year review with invalid year", method: "GET", path: "/review/1800", status: nethttp.StatusBadRequest},
+		{name: "create access token", method: "POST", path: "/tokens", body: `{"name": "Home Assistant", "scopes": ["sessions:write"]}`, status: nethttp.StatusCreated},
+		{name: "create access token with unknown scope", method: "POST", path: "/tokens", body: `{"name": "Home Assistant", "scopes": ["admin"]}`, status: nethttp.StatusBadRequest},
+		{name: "list access tokens", method: "GET", path: "/tokens", status: nethttp.StatusOK},
+		{name: "revoke missing access token", method: "DELETE", path: "/tokens/999", status: nethttp.StatusNotFound},
+		{name: "create reminder", method: "POST", path: "/reminders", body: `{"days": ["monday", "friday"], "local_time": "07:30"}`, status: nethttp.StatusCreated},
+		{name: "create reminder with invalid time", method: "POST", path: "/reminders", body: `{"days": ["monday"], "local_time": "7am"}`, status: nethttp.StatusBadRequest},
+		{name: "list reminders", method: "GET", path: "/reminders", status: nethttp.StatusOK},
+		{name: "update reminder", method: "PATCH", path: "/reminders/1", body: `{"enabled": false}`, status: nethttp.StatusOK},
+		{name: "list reminders with access token", method: "GET", path: "/reminders", headers: withPAT, status: nethttp.StatusForbidden},
+		{name: "delete reminder", method: "DELETE", path: "/reminders/1", status: nethttp.StatusOK},
+		{name: "delete missing reminder", method: "DELETE", path: "/reminders/1", status: nethttp.StatusNotFound},
+		{name: "records with access token", method: "GET", path: "/records", headers: withPAT, status: nethttp.StatusOK},
+		{name: "sessions with access token missing scope", method: "GET", path: "/sessions", headers: withPAT, status: nethttp.StatusForbidden},
+		{name: "delete missing session", method: "DELETE", path: "/sessions/999", status: nethttp.StatusNotFound},
+		{name: "delete session", method: "DELETE", path: fmt.Sprintf("/sessions/%d", session.ID), status: nethttp.StatusOK},
+		{name: "account history", method: "GET", path: "/audit?limit=2", status: nethttp.StatusOK},
+		{name: "account history with access token", method: "GET", path: "/audit", headers: withPAT, status: nethttp.StatusForbidden},
+		{name: "export account", method: "POST", path: "/account/export", status: nethttp.StatusOK},
+		{name: "request account deletion", method: "POST", path: "/account/deletion", status: nethttp.StatusAccepted},
+		{name: "profile with pending deletion", method: "GET", path: "/user/profile", status: nethttp.StatusOK},
+		{name: "cancel account deletion", method: "DELETE", path: "/account/deletion", status: nethttp.StatusOK},
+		{
+			name:   "clerk webhook",
+			method: "POST",
+			path:   "/webhooks/clerk",
+			body:   string(webhookPayload),
+			headers: map[string]string{
+				"svix-timestamp": "1234567890",
+				"svix-signature": testutils.GenerateValidClerkSignature(string(webhookPayload), "1234567890", "test_secret_key"),
+			},
+			status: nethttp.StatusOK,
+		},
+		{name: "clerk webhook without signature", method: "POST", path: "/webhooks/clerk", body: string(webhookPayload), status: nethttp.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run("match spec for "+tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1"+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer contract_token")
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			route, pathParams, err := specRouter.FindRoute(req)
+			require.NoError(t, err)
+
+			requestInput := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+			}
+			// Only well-formed requests are checked so the invalid cases can still exercise error responses
+			if tt.status < nethttp.StatusBadRequest {
+				require.NoError(t, openapi3filter.ValidateRequest(context.Background(), requestInput))
+			}
+			req.Body = io.NopCloser(strings.NewReader(tt.body))
+
+			w := httptest.NewRecorder()
+			server.Handler().ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+
+			err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: requestInput,
+				Status:                 w.Code,
+				Header:                 w.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(w.Body.Bytes())),
+				Options:                &openapi3filter.Options{IncludeResponseStatus: true, MultiError: true},
+			})
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("document every v1 route", func(t *testing.T) {
+		engine, ok := server.Handler().(*gin.Engine)
+		require.True(t, ok)
+
+		for _, r := range engine.Routes() {
+			path, found := strings.CutPrefix(r.Path, "/api/v1")
+			if !found {
+				continue
+			}
+
+			// Convert gin's :param syntax to OpenAPI's {param}
+			segments := strings.Split(path, "/")
+			for i, segment := range segments {
+				if name, isParam := strings.CutPrefix(segment, ":"); isParam {
+					segments[i] = "{" + name + "}"
+				}
+			}
+
+			item := doc.Paths.Find(strings.Join(segments, "/"))
+			if assert.NotNil(t, item, "%s %s is not in the spec", r.Method, r.Path) {
+				assert.NotNil(t, item.GetOperation(r.Method), "%s %s is not in the spec", r.Method, r.Path)
+			}
+		}
+	})
+
+	t.Run("serve the spec as json", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/api/openapi.json", nil))
+
+		assert.Equal(t, nethttp.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `"openapi":"3.0.3"`)
+	})
+
+	t.Run("hide the docs ui unless enabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/api/docs", nil))
+
+		assert.Equal(t, nethttp.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	nethttp "net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mindful-minutes/mindful-minutes-api/api"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/ratelimit"
+)
+
+// aliasDeprecatedAt is when the unversioned /api routes were superseded by /api/v1
+var aliasDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+// route is a single API endpoint, relative to its version prefix
+type route struct {
+	method  string
+	path    string
+	handler gin.HandlerFunc
+	// public routes skip authentication
+	public bool
+	// scope is what a personal access token needs to call the route; routes without one are
+	// only available to Clerk session tokens
+	scope string
+	// access is the audit action recorded when an admin reads data through the route
+	access string
+}
+
+// apiVersion is a route tree mounted under a prefix. It inherits routes from an earlier
+// version and overrides the endpoints whose behaviour changes, so v2 only lists its differences.
+type apiVersion struct {
+	prefix      string
+	routes      []route
+	overrides   []route
+	deprecation *deprecation
+}
+
+// deprecation describes the Deprecation (RFC 9745) and Sunset (RFC 8594) headers sent on a version.
+// successor is the prefix clients should move to, advertised in a Link header.
+type deprecation struct {
+	since     time.Time
+	sunset    time.Time
+	successor string
+}
+
+func (s *Server) v1Routes() []route {
+	routes := []route{
+		{method: nethttp.MethodGet, path: "/ping", handler: func(c *gin.Context) {
+			c.JSON(200, gin.H{
+				"message": "pong",
+			})
+		}, public: true},
+
+		// User routes
+		{method: nethttp.MethodGet, path: "/user/profile", handler: s.handler.GetUserProfile, scope: auth.ScopeProfileRead},
+		{method: nethttp.MethodGet, path: "/user/preferences", handler: s.handler.GetPreferences, scope: auth.ScopeProfileRead},
+		// Preferences can only be changed with a session token
+		{method: nethttp.MethodPatch, path: "/user/preferences", handler: s.handler.UpdatePreferences},
+
+		// Reminders can only be managed with a session token
+		{method: nethttp.MethodGet, path: "/reminders", handler: s.handler.GetReminders},
+		{method: nethttp.MethodPost, path: "/reminders", handler: s.handler.CreateReminder},
+		{method: nethttp.MethodPatch, path: "/reminders/:id", handler: s.handler.UpdateReminder},
+		{method: nethttp.MethodDelete, path: "/reminders/:id", handler: s.handler.DeleteReminder},
+
+		// Personal access tokens can only be managed with a session token
+		{method: nethttp.MethodPost, path: "/tokens", handler: s.handler.CreateAccessToken},
+		{method: nethttp.MethodGet, path: "/tokens", handler: s.handler.GetAccessTokens},
+		{method: nethttp.MethodDelete, path: "/tokens/:id", handler: s.handler.RevokeAccessToken},
+
+		// A user's account history is only available with a session token
+		{method: nethttp.MethodGet, path: "/audit", handler: s.handler.GetAuditEvents},
+
+		// Data export and account deletion need a session token
+		{method: nethttp.MethodPost, path: "/account/export", handler: s.handler.ExportAccount},
+		{method: nethttp.MethodPost, path: "/account/deletion", handler: s.handler.RequestAccountDeletion},
+		{method: nethttp.MethodDelete, path: "/account/deletion", handler: s.handler.CancelAccountDeletion},
+
+		// Session routes
+		{method: nethttp.MethodPost, path: "/sessions", handler: s.handler.CreateSession, scope: auth.ScopeSessionsWrite},
+		{method: nethttp.MethodGet, path: "/sessions", handler: s.handler.GetSessions, scope: auth.ScopeSessionsRead},
+		{method: nethttp.MethodDelete, path: "/sessions/:id", handler: s.handler.DeleteSession, scope: auth.ScopeSessionsWrite},
+
+		// Dashboard routes
+		{method: nethttp.MethodGet, path: "/dashboard", handler: s.handler.GetDashboard, scope: auth.ScopeStatsRead},
+		{method: nethttp.MethodGet, path: "/records", handler: s.handler.GetRecords, scope: auth.ScopeStatsRead},
+		{method: nethttp.MethodGet, path: "/achievements", handler: s.handler.GetAchievements, scope: auth.ScopeStatsRead},
+		{method: nethttp.MethodGet, path: "/review/:year", handler: s.handler.GetYearReview, scope: auth.ScopeStatsRead},
+	}
+
+	// Webhooks are authenticated by signature rather than by user token
+	if webhook := s.auth.WebhookHandler(s.repos.Users, s.repos.WebhookEvents); webhook != nil {
+		routes = append(routes, route{method: nethttp.MethodPost, path: "/webhooks/" + s.auth.Name(), handler: webhook, public: true})
+	}
+
+	return routes
+}
+
+// adminRoutes are the support staff routes mounted under /api/admin. They are unversioned,
+// need the admin role and a session token, and every call is audited.
+func (s *Server) adminRoutes() []route {
+	return []route{
+		{method: nethttp.MethodGet, path: "/users", handler: s.handler.AdminSearchUsers, access: "admin.users_searched"},
+		{method: nethttp.MethodGet, path: "/users/:id", handler: s.handler.AdminGetUser, access: "admin.user_viewed"},
+		{method: nethttp.MethodPost, path: "/users/:id/restore", handler: s.handler.AdminRestoreUser},
+		{method: nethttp.MethodGet, path: "/users/:id/sessions", handler: s.handler.AdminGetUserSessions, access: "admin.sessions_viewed"},
+		{method: nethttp.MethodPost, path: "/users/:id/sessions/:session_id/restore", handler: s.handler.AdminRestoreSession},
+		{method: nethttp.MethodGet, path: "/users/:id/stats", handler: s.handler.AdminGetUserStats, access: "admin.stats_viewed"},
+		{method: nethttp.MethodGet, path: "/stats", handler: s.handler.AdminGetStats, access: "admin.service_stats_viewed"},
+	}
+}
+
+// apiVersions lists every mounted route tree. A future version reuses v1's routes and overrides
+// only what changes, e.g.
+//
+//	{prefix: "/api/v2", routes: v1, overrides: []route{{method: nethttp.MethodGet, path: "/dashboard", handler: s.handler.GetDashboardV2}}}
+func (s *Server) apiVersions() []apiVersion {
+	v1 := s.v1Routes()
+
+	return []apiVersion{
+		{prefix: "/api/v1", routes: v1},
+		// Unversioned alias kept for clients released before /api/v1 existed
+		{prefix: "/api", routes: v1, deprecation: &deprecation{
+			since:     aliasDeprecatedAt,
+			sunset:    s.config.Server.APIAliasSunset,
+			successor: "/api/v1",
+		}},
+	}
+}
+
+// withOverrides returns base with handlers replaced for routes matching an override's method
+// and path. Overrides that match nothing are added as new routes.
+func withOverrides(base []route, overrides ...route) []route {
+	routes := append([]route(nil), base...)
+	for _, override := range overrides {
+		replaced := false
+		for i, r := range routes {
+			if r.method == override.method && r.path == override.path {
+				routes[i] = override
+				replaced = true
+			}
+		}
+		if !replaced {
+			routes = append(routes, override)
+		}
+	}
+
+	return routes
+}
+
+func (s *Server) setupRoutes() {
+	// Prometheus scrape endpoint, kept off the /api prefix like the health probes
+	s.router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// The specification describes /api/v1 and is shared by every version prefix
+	s.router.GET("/api/openapi.json", func(c *gin.Context) {
+		spec, err := api.SpecJSON()
+		if err != nil {
+			apierror.Abort(c, apierror.Internal("Failed to load API specification", err))
+
+			return
+		}
+
+		c.Data(nethttp.StatusOK, "application/json", spec)
+	})
+	if s.config.Server.APIDocs {
+		s.router.GET("/api/docs", func(c *gin.Context) {
+			c.Data(nethttp.StatusOK, "text/html; charset=utf-8", api.DocsHTML)
+		})
+	}
+
+	// Tokens are verified with the identity provider, so clients are limited by IP before that
+	authLimit := ratelimit.Middleware(s.rateLimits, "auth", ratelimit.Limit(s.config.RateLimit.Auth))
+	authenticate := auth.AuthMiddleware(s.auth, s.repos.Users, s.repos.AccessTokens)
+	for _, version := range s.apiVersions() {
+		group := s.router.Group(version.prefix)
+		if version.deprecation != nil {
+			group.Use(deprecationHeaders(version.prefix, *version.deprecation))
+		}
+
+		for _, r := range withOverrides(version.routes, version.overrides...) {
+			limit := s.rateLimit(r)
+			if r.public {
+				group.Handle(r.method, r.path, limit, r.handler)
+			} else {
+				// Limiting after authentication lets authenticated routes be limited per user
+				group.Handle(r.method, r.path, authLimit, authenticate, auth.RequireScope(r.scope), limit, r.handler)
+			}
+		}
+	}
+
+	admin := s.router.Group("/api/admin")
+	for _, r := range s.adminRoutes() {
+		admin.Handle(r.method, r.path, authLimit, authenticate, auditAdmin(s.repos.AuditEvents, r.access), auth.RequireScope(r.scope), auth.RequireRole(models.RoleAdmin), s.rateLimit(r), r.handler)
+	}
+}
+
+// rateLimit picks the limit for a route's group. Buckets are shared between version prefixes,
+// so clients can't double their allowance by mixing /api and /api/v1.
+func (s *Server) rateLimit(r route) gin.HandlerFunc {
+	limits := s.config.RateLimit
+	group, limit := "read", limits.Read
+	switch {
+	case r.public:
+		group, limit = "public", limits.Public
+	case r.method != nethttp.MethodGet:
+		group, limit = "write", limits.Write
+	}
+
+	return ratelimit.Middleware(s.rateLimits, group, ratelimit.Limit(limit))
+}
+
+// deprecationHeaders marks every response under prefix as deprecated and points at the successor route
+func deprecationHeaders(prefix string, d deprecation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "@"+strconv.FormatInt(d.since.Unix(), 10))
+		if !d.sunset.IsZero() {
+			c.Header("Sunset", d.sunset.UTC().Format(nethttp.TimeFormat))
+		}
+		if d.successor != "" {
+			successor := d.successor + strings.TrimPrefix(c.Request.URL.Path, prefix)
+			c.Header("Link", "<"+successor+`>; rel="successor-version"`)
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,90 @@
+package http_test
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/http"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+)
+
+func TestAPIVersions(t *testing.T) {
+	serve := func(server *http.Server, method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest(method, path, nil))
+
+		return w
+	}
+
+	t.Run("serve v1 routes without deprecation headers", func(t *testing.T) {
+		w := serve(newTestServer(0), "GET", "/api/v1/ping")
+
+		assert.Equal(t, nethttp.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "pong")
+		assert.Empty(t, w.Header().Get("Deprecation"))
+	})
+
+	t.Run("require authentication on protected v1 routes", func(t *testing.T) {
+		w := serve(newTestServer(0), "GET", "/api/v1/dashboard")
+
+		assert.Equal(t, nethttp.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "MISSING_AUTHORIZATION")
+	})
+
+	t.Run("serve unversioned alias with deprecation and successor link", func(t *testing.T) {
+		w := serve(newTestServer(0), "GET", "/api/ping")
+
+		assert.Equal(t, nethttp.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "pong")
+		assert.Regexp(t, `^@\d+$`, w.Header().Get("Deprecation"))
+		assert.Equal(t, `</api/v1/ping>; rel="successor-version"`, w.Header().Get("Link"))
+		assert.Empty(t, w.Header().Get("Sunset"))
+	})
+
+	t.Run("mark failed alias responses as deprecated too", func(t *testing.T) {
+		w := serve(newTestServer(0), "DELETE", "/api/sessions/42")
+
+		assert.Equal(t, nethttp.StatusUnauthorized, w.Code)
+		assert.Equal(t, `</api/v1/sessions/42>; rel="successor-version"`, w.Header().Get("Link"))
+	})
+
+	t.Run("announce sunset on alias when configured", func(t *testing.T) {
+		cfg := &config.Config{
+			Server: config.ServerConfig{
+				GinMode:        gin.TestMode,
+				APIAliasSunset: time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC),
+			},
+		}
+		server := http.NewServer(cfg, memory.NewRepositories(), func(ctx context.Context) error {
+			return nil
+		})
+
+		w := serve(server, "GET", "/api/ping")
+
+		assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+	})
+
+	t.Run("share rate limit buckets between version prefixes", func(t *testing.T) {
+		cfg := &config.Config{
+			Server:    config.ServerConfig{GinMode: gin.TestMode},
+			RateLimit: config.RateLimitConfig{Public: config.RateLimit{Requests: 1, Period: time.Minute}},
+		}
+		server := http.NewServer(cfg, memory.NewRepositories(), func(ctx context.Context) error {
+			return nil
+		})
+
+		first := serve(server, "GET", "/api/v1/ping")
+		second := serve(server, "GET", "/api/ping")
+
+		assert.Equal(t, nethttp.StatusOK, first.Code)
+		assert.Equal(t, nethttp.StatusTooManyRequests, second.Code)
+		assert.Equal(t, "60", second.Header().Get("Retry-After"))
+	})
+}
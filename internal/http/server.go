@@ -2,47 +2,166 @@ package http
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	"net"
+	nethttp "net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hellofresh/health-go/v5"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/audit"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
-	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/handlers"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/logging"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/notify"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/ratelimit"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/cache"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
 )
 
 type Server struct {
-	router *gin.Engine
-	config *config.Config
+	router      *gin.Engine
+	config      *config.Config
+	repos       *repository.Repositories
+	handler     *handlers.Handler
+	healthCheck func(ctx context.Context) error
+	rateLimits  ratelimit.Store
+	auth        auth.Provider
+	// notifications delivers reminders sent by the scheduler
+	notifications notify.Dispatcher
+
+	// shuttingDown fails the readiness probe once shutdown has begun
+	shuttingDown atomic.Bool
+	workers      []worker
 }
 
-func NewServer() (*Server, error) {
-	// Load configuration
-	cfg, err := config.Load()
-	if err != nil {
-		return nil, err
+// worker is a background task that runs alongside the HTTP server until shutdown
+type worker struct {
+	name string
+	run  func(ctx context.Context)
+}
+
+// Option customises a Server in NewServer
+type Option func(*Server)
+
+// WithRateLimitStore replaces the in-memory rate limit store, e.g. with a shared store so that
+// replicas enforce one limit between them
+func WithRateLimitStore(store ratelimit.Store) Option {
+	return func(s *Server) {
+		s.rateLimits = store
+	}
+}
+
+// WithAuthProvider replaces the Clerk provider built from the config, e.g. with an OIDC provider
+func WithAuthProvider(provider auth.Provider) Option {
+	return func(s *Server) {
+		s.auth = provider
+	}
+}
+
+// WithNotificationDispatcher replaces the dispatcher built from the config that reminders are sent through
+func WithNotificationDispatcher(dispatcher notify.Dispatcher) Option {
+	return func(s *Server) {
+		s.notifications = dispatcher
 	}
+}
 
+// NewServer wires the router around the given repositories. healthCheck reports whether
+// the backing store is reachable and drives the readiness probe.
+func NewServer(cfg *config.Config, repos *repository.Repositories, healthCheck func(ctx context.Context) error, opts ...Option) *Server {
 	// Set Gin mode
 	gin.SetMode(cfg.Server.GinMode)
 
+	// Account changes made through the API are recorded in the audit log
+	repos = audit.Wrap(repos)
+
+	// Authentication looks the user up on every request, so the lookup is cached. The cache wraps
+	// the shared repository so the webhook's writes evict what they change.
+	if cfg.Auth.UserCache.Enabled {
+		cached := *repos
+		cached.Users = cache.NewUserRepository(repos.Users, cfg.Auth.UserCache.TTL)
+		repos = &cached
+	}
+
 	server := &Server{
-		router: gin.Default(),
-		config: cfg,
+		router:      gin.New(),
+		config:      cfg,
+		repos:       repos,
+		handler:     handlers.New(repos, handlers.WithDeletionGracePeriod(cfg.Account.DeletionGracePeriod)),
+		healthCheck: healthCheck,
+		rateLimits:  ratelimit.NewMemoryStore(),
+		auth:        auth.NewClerkProvider(cfg),
+	}
+	// Without a push service reminders are only logged
+	if cfg.Reminders.WebhookURL != "" {
+		server.notifications = notify.NewWebhookDispatcher(cfg.Reminders.WebhookURL, cfg.Reminders.WebhookSecret)
+	} else {
+		server.notifications = notify.LogDispatcher{}
+	}
+	for _, opt := range opts {
+		opt(server)
+	}
+
+	// The trace span wraps everything so it covers the full request. Request IDs and the audit source come
+	// next so the access log, panic recovery and audit events can include them. Metrics sit outside recovery so panics are counted as 500s.
+	server.router.Use(
+		otelgin.Middleware(cfg.Tracing.ServiceName, otelgin.WithFilter(isTraced)),
+		logging.RequestIDMiddleware(),
+		audit.Middleware(),
+		logging.AccessLog(slog.Default()),
+		metrics.Middleware(),
+		apierror.Recovery(slog.Default()),
+		securityHeaders(cfg.Server.HSTSMaxAge),
+	)
+	// Global middleware also runs for unmatched methods, so preflight OPTIONS requests are answered
+	// here rather than reaching the 405 handler
+	if cfg.CORS.Enabled() {
+		server.router.Use(corsPolicy(cfg.CORS))
+	}
+	// Only X-Forwarded-For headers added by these proxies are believed; with none configured the
+	// client IP is the connection's remote address, so it can't be spoofed. The list is validated in config.
+	if err := server.router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		slog.Error("invalid trusted proxies, trusting none", slog.String("error", err.Error()))
+		_ = server.router.SetTrustedProxies(nil)
+	}
+	server.router.HandleMethodNotAllowed = true
+	server.router.NoRoute(apierror.NoRoute)
+	server.router.NoMethod(apierror.NoMethod)
+
+	// The purge shares the server's repositories so the user cache evicts purged accounts
+	if cfg.Account.PurgeInterval > 0 {
+		accounts := services.NewAccountService(repos, cfg.Account.DeletionGracePeriod)
+		server.AddWorker("account purge", func(ctx context.Context) {
+			accounts.RunPurge(ctx, cfg.Account.PurgeInterval)
+		})
 	}
 
-	// Connect to database
-	if err := database.Connect(cfg.Database.URL); err != nil {
-		return nil, err
+	if cfg.Reminders.Interval > 0 {
+		reminders := services.NewReminderService(repos, server.notifications)
+		server.AddWorker("reminders", func(ctx context.Context) {
+			reminders.Run(ctx, cfg.Reminders.Interval)
+		})
 	}
 
 	server.setupHealthChecks()
 	server.setupRoutes()
 
-	return server, nil
+	return server
+}
+
+// isTraced skips probe and scrape traffic, which would otherwise dominate sampled traces
+func isTraced(r *nethttp.Request) bool {
+	return !strings.HasPrefix(r.URL.Path, "/health/") && r.URL.Path != "/metrics"
 }
 
 func (s *Server) setupHealthChecks() {
@@ -57,9 +176,7 @@ func (s *Server) setupHealthChecks() {
 				Name:      "postgres",
 				Timeout:   time.Second * 2,
 				SkipOnErr: false,
-				Check: func(ctx context.Context) error {
-					return database.IsHealthy()
-				},
+				Check:     s.healthCheck,
 			},
 		),
 	)
@@ -72,46 +189,104 @@ func (s *Server) setupHealthChecks() {
 
 	// Readiness check - check that the service is ready to serve traffic (includes DB)
 	s.router.GET("/health/readiness", func(c *gin.Context) {
+		if s.shuttingDown.Load() {
+			c.JSON(nethttp.StatusServiceUnavailable, gin.H{"status": "Unavailable", "reason": "shutting down"})
+
+			return
+		}
+
 		h.Handler().ServeHTTP(c.Writer, c.Request)
 	})
 }
 
-func (s *Server) setupRoutes() {
-	// Webhooks (no auth required)
-	webhooks := s.router.Group("/api/webhooks")
-	{
-		webhooks.POST("/clerk", auth.VerifyClerkWebhook(s.config))
+// Handler returns the server's HTTP handler, for tests that exercise routes without a listener
+func (s *Server) Handler() nethttp.Handler {
+	return s.router
+}
+
+// AddWorker registers a background task started with the server. Its context is cancelled
+// during shutdown once in-flight requests have drained, and Start waits for it to return.
+func (s *Server) AddWorker(name string, run func(ctx context.Context)) {
+	s.workers = append(s.workers, worker{name: name, run: run})
+}
+
+// Start serves HTTP until ctx is cancelled, then shuts down gracefully: readiness starts failing,
+// the listener stays open for ShutdownDelay so load balancers stop routing traffic here, in-flight
+// requests drain and background workers stop, all within ShutdownTimeout.
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", ":"+s.config.Server.Port)
+	if err != nil {
+		return err
 	}
 
-	// API routes
-	api := s.router.Group("/api")
-	{
-		api.GET("/ping", func(c *gin.Context) {
-			c.JSON(200, gin.H{
-				"message": "pong",
-			})
-		})
+	return s.Serve(ctx, listener)
+}
+
+// Serve is Start on an existing listener
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	httpServer := &nethttp.Server{
+		Handler:           s.router,
+		ReadTimeout:       s.config.Server.ReadTimeout,
+		ReadHeaderTimeout: s.config.Server.ReadHeaderTimeout,
+		WriteTimeout:      s.config.Server.WriteTimeout,
+		IdleTimeout:       s.config.Server.IdleTimeout,
+	}
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	var workers sync.WaitGroup
+	for _, w := range s.workers {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			slog.Info("starting worker", slog.String("worker", w.name))
+			w.run(workerCtx)
+			slog.Info("worker stopped", slog.String("worker", w.name))
+		}()
 	}
 
-	// Protected API routes (require authentication)
-	protected := s.router.Group("/api")
-	protected.Use(auth.AuthMiddleware(s.config))
-	{
-		// User routes
-		protected.GET("/user/profile", handlers.GetUserProfile)
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("server starting", slog.String("address", listener.Addr().String()))
+		serveErr <- httpServer.Serve(listener)
+	}()
 
-		// Session routes
-		protected.POST("/sessions", handlers.CreateSession)
-		protected.GET("/sessions", handlers.GetSessions)
-		protected.DELETE("/sessions/:id", handlers.DeleteSession)
+	select {
+	case err := <-serveErr:
+		stopWorkers()
+		workers.Wait()
 
-		// Dashboard routes
-		protected.GET("/dashboard", handlers.GetDashboard)
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down, readiness now failing", slog.Duration("shutdown_delay", s.config.Server.ShutdownDelay))
+	s.shuttingDown.Store(true)
+	time.Sleep(s.config.Server.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.Server.ShutdownTimeout)
+	defer cancel()
+
+	shutdownErr := httpServer.Shutdown(shutdownCtx)
+	if err := <-serveErr; !errors.Is(err, nethttp.ErrServerClosed) {
+		shutdownErr = errors.Join(shutdownErr, err)
+	}
+
+	stopWorkers()
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
+
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		shutdownErr = errors.Join(shutdownErr, errors.New("background workers did not stop before the shutdown deadline"))
 	}
-}
 
-func (s *Server) Start() error {
-	log.Printf("Server starting on port %s", s.config.Server.Port)
+	slog.Info("server stopped")
 
-	return s.router.Run(":" + s.config.Server.Port)
+	return shutdownErr
 }
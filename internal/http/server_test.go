@@ -0,0 +1,118 @@
+package http_test
+
+import (
+	"context"
+	"net"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/http"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+)
+
+func newTestServer(shutdownDelay time.Duration) *http.Server {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Port:            "0",
+			GinMode:         gin.TestMode,
+			ShutdownDelay:   shutdownDelay,
+			ShutdownTimeout: time.Second,
+		},
+	}
+
+	return http.NewServer(cfg, memory.NewRepositories(), func(ctx context.Context) error {
+		return nil
+	})
+}
+
+func readinessStatus(server *http.Server) int {
+	w := httptest.NewRecorder()
+	server.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/health/readiness", nil))
+
+	return w.Code
+}
+
+func TestServerShutdown(t *testing.T) {
+	t.Run("fail readiness while draining and stop workers before returning", func(t *testing.T) {
+		server := newTestServer(200 * time.Millisecond)
+
+		workerStopped := make(chan struct{})
+		server.AddWorker("test", func(ctx context.Context) {
+			<-ctx.Done()
+			close(workerStopped)
+		})
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- server.Serve(ctx, listener)
+		}()
+
+		assert.Equal(t, nethttp.StatusOK, readinessStatus(server))
+
+		cancel()
+
+		assert.Eventually(t, func() bool {
+			return readinessStatus(server) == nethttp.StatusServiceUnavailable
+		}, time.Second, 10*time.Millisecond)
+
+		select {
+		case <-workerStopped:
+			t.Fatal("worker stopped before the shutdown delay elapsed")
+		default:
+		}
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("server did not stop")
+		}
+
+		select {
+		case <-workerStopped:
+		default:
+			t.Fatal("worker was not stopped")
+		}
+	})
+
+	t.Run("stop accepting connections once shut down", func(t *testing.T) {
+		server := newTestServer(0)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- server.Serve(ctx, listener)
+		}()
+
+		// Open a keep-alive connection and make a request so the server is known to be serving
+		resp, err := nethttp.Get("http://" + listener.Addr().String() + "/health/liveness")
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, nethttp.StatusOK, resp.StatusCode)
+
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("server did not stop")
+		}
+
+		_, err = nethttp.Get("http://" + listener.Addr().String() + "/health/liveness")
+		assert.Error(t, err)
+	})
+}
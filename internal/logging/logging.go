@@ -0,0 +1,107 @@
+// Package logging configures structured slog logging and carries per-request fields,
+// such as the request ID and authenticated user ID, through the request context.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// ParseLevel converts a level name such as "debug" or "warn" into a slog.Level
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(name))); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+
+	return level, nil
+}
+
+// New returns a logger writing JSON (or text) records at or above level to w.
+// Records logged with a request context include its request_id and user_id.
+func New(w io.Writer, format string, level slog.Level) *slog.Logger {
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	if format == "text" {
+		handler = slog.NewTextHandler(w, options)
+	} else {
+		handler = slog.NewJSONHandler(w, options)
+	}
+
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// fields holds the request-scoped values added to every log record made with the request context.
+// It is stored by pointer so middleware further down the chain can fill in the user ID.
+type fields struct {
+	mu        sync.RWMutex
+	requestID string
+	userID    string
+}
+
+type fieldsKey struct{}
+
+// WithRequestID returns a context whose log records carry the given request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, &fields{requestID: requestID})
+}
+
+// RequestID returns the request ID stored in the context, if any
+func RequestID(ctx context.Context) string {
+	f, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok {
+		return ""
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.requestID
+}
+
+// SetUserID records the authenticated user on the request's log fields.
+// It is a no-op for contexts that did not pass through the request ID middleware.
+func SetUserID(ctx context.Context, userID string) {
+	f, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.userID = userID
+}
+
+// contextHandler adds request-scoped fields from the context to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if f, ok := ctx.Value(fieldsKey{}).(*fields); ok {
+		f.mu.RLock()
+		if f.requestID != "" {
+			record.AddAttrs(slog.String("request_id", f.requestID))
+		}
+		if f.userID != "" {
+			record.AddAttrs(slog.String("user_id", f.userID))
+		}
+		f.mu.RUnlock()
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,179 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/logging"
+)
+
+// decodeRecords parses one JSON log record per line
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Run("return level when name is known", func(t *testing.T) {
+		level, err := logging.ParseLevel("warn")
+
+		assert.NoError(t, err)
+		assert.Equal(t, slog.LevelWarn, level)
+	})
+
+	t.Run("return error when name is unknown", func(t *testing.T) {
+		_, err := logging.ParseLevel("verbose")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRequestLogging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setupRouter := func(buf *bytes.Buffer) *gin.Engine {
+		logger := logging.New(buf, "json", slog.LevelInfo)
+
+		router := gin.New()
+		router.Use(logging.RequestIDMiddleware(), logging.AccessLog(logger))
+		router.GET("/sessions/:id", func(c *gin.Context) {
+			logging.SetUserID(c.Request.Context(), "user_123")
+			c.JSON(http.StatusOK, gin.H{"request_id": logging.RequestID(c.Request.Context())})
+		})
+
+		return router
+	}
+
+	t.Run("generate request id when header is missing", func(t *testing.T) {
+		var buf bytes.Buffer
+		router := setupRouter(&buf)
+
+		req := httptest.NewRequest("GET", "/sessions/1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		requestID := w.Header().Get(logging.RequestIDHeader)
+		assert.Len(t, requestID, 26)
+		assert.Contains(t, w.Body.String(), requestID)
+	})
+
+	t.Run("reuse request id when header is valid", func(t *testing.T) {
+		var buf bytes.Buffer
+		router := setupRouter(&buf)
+
+		req := httptest.NewRequest("GET", "/sessions/1", nil)
+		req.Header.Set(logging.RequestIDHeader, "abc-123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "abc-123", w.Header().Get(logging.RequestIDHeader))
+	})
+
+	t.Run("replace request id when header is malformed", func(t *testing.T) {
+		var buf bytes.Buffer
+		router := setupRouter(&buf)
+
+		req := httptest.NewRequest("GET", "/sessions/1", nil)
+		req.Header.Set(logging.RequestIDHeader, "bad id\nwith newline")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.NotEqual(t, "bad id\nwith newline", w.Header().Get(logging.RequestIDHeader))
+		assert.Len(t, w.Header().Get(logging.RequestIDHeader), 26)
+	})
+
+	t.Run("log request with route, request id and user id", func(t *testing.T) {
+		var buf bytes.Buffer
+		router := setupRouter(&buf)
+
+		req := httptest.NewRequest("GET", "/sessions/42", nil)
+		req.Header.Set(logging.RequestIDHeader, "abc-123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		records := decodeRecords(t, &buf)
+		assert.Len(t, records, 1)
+		assert.Equal(t, "request completed", records[0]["msg"])
+		assert.Equal(t, "INFO", records[0]["level"])
+		assert.Equal(t, "/sessions/:id", records[0]["route"])
+		assert.Equal(t, float64(http.StatusOK), records[0]["status"])
+		assert.Equal(t, "abc-123", records[0]["request_id"])
+		assert.Equal(t, "user_123", records[0]["user_id"])
+	})
+}
+
+func TestGormLogger(t *testing.T) {
+	trace := func(gormLogger *logging.GormLogger, elapsed time.Duration, err error) {
+		gormLogger.Trace(context.Background(), time.Now().Add(-elapsed), func() (string, int64) {
+			return "SELECT 1", 1
+		}, err)
+	}
+
+	t.Run("log slow query as warning", func(t *testing.T) {
+		var buf bytes.Buffer
+		gormLogger := logging.NewGormLogger(logging.New(&buf, "json", slog.LevelInfo), 100*time.Millisecond)
+
+		trace(gormLogger, 200*time.Millisecond, nil)
+
+		records := decodeRecords(t, &buf)
+		assert.Len(t, records, 1)
+		assert.Equal(t, "slow query", records[0]["msg"])
+		assert.Equal(t, "WARN", records[0]["level"])
+		assert.Equal(t, "SELECT 1", records[0]["sql"])
+	})
+
+	t.Run("log failed query as error", func(t *testing.T) {
+		var buf bytes.Buffer
+		gormLogger := logging.NewGormLogger(logging.New(&buf, "json", slog.LevelInfo), 100*time.Millisecond)
+
+		trace(gormLogger, time.Millisecond, errors.New("connection refused"))
+
+		records := decodeRecords(t, &buf)
+		assert.Len(t, records, 1)
+		assert.Equal(t, "query failed", records[0]["msg"])
+		assert.Equal(t, "connection refused", records[0]["error"])
+	})
+
+	t.Run("skip fast queries and not found errors above debug level", func(t *testing.T) {
+		var buf bytes.Buffer
+		gormLogger := logging.NewGormLogger(logging.New(&buf, "json", slog.LevelInfo), 100*time.Millisecond)
+
+		trace(gormLogger, time.Millisecond, nil)
+		trace(gormLogger, time.Millisecond, gorm.ErrRecordNotFound)
+
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("log every query at debug level", func(t *testing.T) {
+		var buf bytes.Buffer
+		gormLogger := logging.NewGormLogger(logging.New(&buf, "json", slog.LevelDebug), 100*time.Millisecond)
+
+		trace(gormLogger, time.Millisecond, nil)
+
+		records := decodeRecords(t, &buf)
+		assert.Len(t, records, 1)
+		assert.Equal(t, "query", records[0]["msg"])
+	})
+}
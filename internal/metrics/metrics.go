@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "mindful_minutes"
+
+// Registry holds every metric exposed on /metrics, including Go runtime and process stats
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency, by method, route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	clerkVerificationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "clerk_verification_duration_seconds",
+		Help:      "Latency of Clerk token verification, by outcome (success, rejected, error).",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"outcome"})
+
+	webhookEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_events_total",
+		Help:      "Clerk webhook events received, by event type and outcome (processed, failed, rejected).",
+	}, []string{"type", "outcome"})
+
+	usersProvisioned = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "users_provisioned_total",
+		Help:      "Users created on their first authenticated request rather than by webhook, by auth provider.",
+	}, []string{"provider"})
+
+	userCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_cache_lookups_total",
+		Help:      "Authenticated user lookups served by the user cache, by result (hit, miss).",
+	}, []string{"result"})
+
+	sessionsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sessions_created_total",
+		Help:      "Meditation sessions created, by session type.",
+	}, []string{"session_type"})
+
+	accountsPurged = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "accounts_purged_total",
+		Help:      "Deleted accounts permanently removed once their grace period ended.",
+	})
+
+	reminders = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reminders_total",
+		Help:      "Due meditation reminders, by outcome (sent, meditated, quiet_hours, failed).",
+	}, []string{"outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpRequestDuration,
+		clerkVerificationDuration,
+		webhookEvents,
+		usersProvisioned,
+		userCacheLookups,
+		sessionsCreated,
+		accountsPurged,
+		reminders,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// RegisterDB exposes connection pool stats for the given database
+func RegisterDB(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Middleware counts and times requests. Routes are labelled by template so IDs don't explode cardinality.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		labels := prometheus.Labels{
+			"method": c.Request.Method,
+			"route":  route,
+			"status": strconv.Itoa(c.Writer.Status()),
+		}
+
+		httpRequests.With(labels).Inc()
+		httpRequestDuration.With(labels).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveClerkVerification records how long a token verification took and how it ended
+func ObserveClerkVerification(outcome string, elapsed time.Duration) {
+	clerkVerificationDuration.WithLabelValues(outcome).Observe(elapsed.Seconds())
+}
+
+// WebhookEvent counts a received webhook event
+func WebhookEvent(eventType, outcome string) {
+	webhookEvents.WithLabelValues(eventType, outcome).Inc()
+}
+
+// UserProvisioned counts a user created just in time by an auth provider
+func UserProvisioned(provider string) {
+	usersProvisioned.WithLabelValues(provider).Inc()
+}
+
+// UserCacheLookup counts a user cache hit or miss
+func UserCacheLookup(result string) {
+	userCacheLookups.WithLabelValues(result).Inc()
+}
+
+// SessionCreated counts a newly stored session
+func SessionCreated(sessionType string) {
+	sessionsCreated.WithLabelValues(sessionType).Inc()
+}
+
+// AccountPurged counts an account permanently removed by the purge
+func AccountPurged() {
+	accountsPurged.Inc()
+}
+
+// Reminder counts a due reminder by what the scheduler did with it
+func Reminder(outcome string) {
+	reminders.WithLabelValues(outcome).Inc()
+}
@@ -0,0 +1,69 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
+)
+
+// scrape returns the current exposition output of the metrics registry
+func scrape(t *testing.T) string {
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	return w.Body.String()
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(metrics.Middleware())
+	router.GET("/metrics-test/:id", func(c *gin.Context) {
+		c.Status(http.StatusAccepted)
+	})
+
+	t.Run("label requests by route template and status", func(t *testing.T) {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/metrics-test/1", nil))
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/metrics-test/2", nil))
+
+		body := scrape(t)
+		assert.Contains(t, body, `mindful_minutes_http_requests_total{method="GET",route="/metrics-test/:id",status="202"} 2`)
+		assert.Contains(t, body, `mindful_minutes_http_request_duration_seconds_count{method="GET",route="/metrics-test/:id",status="202"} 2`)
+		assert.NotContains(t, body, `route="/metrics-test/1"`)
+	})
+
+	t.Run("label unknown paths as unmatched", func(t *testing.T) {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/no-such-route", nil))
+
+		assert.Contains(t, scrape(t), `route="unmatched",status="404"`)
+	})
+}
+
+func TestRecorders(t *testing.T) {
+	t.Run("expose business and dependency metrics", func(t *testing.T) {
+		metrics.SessionCreated("walking")
+		metrics.WebhookEvent("user.created", "processed")
+		metrics.ObserveClerkVerification("rejected", 10*time.Millisecond)
+		metrics.UserProvisioned("clerk")
+		metrics.UserCacheLookup("hit")
+
+		body := scrape(t)
+		assert.Contains(t, body, `mindful_minutes_sessions_created_total{session_type="walking"}`)
+		assert.Contains(t, body, `mindful_minutes_webhook_events_total{outcome="processed",type="user.created"}`)
+		assert.Contains(t, body, `mindful_minutes_clerk_verification_duration_seconds_count{outcome="rejected"}`)
+		assert.Contains(t, body, `mindful_minutes_users_provisioned_total{provider="clerk"}`)
+		assert.Contains(t, body, `mindful_minutes_user_cache_lookups_total{result="hit"}`)
+	})
+
+	t.Run("include go runtime metrics", func(t *testing.T) {
+		assert.Contains(t, scrape(t), "go_goroutines")
+	})
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// AccessToken is a personal access token a user created for an integration. Only a hash of
+// the token is stored; Prefix keeps enough of it for the user to recognise which token is which.
+type AccessToken struct {
+	ID         uint       `json:"id" gorm:"primary_key"`
+	UserID     string     `json:"-" gorm:"type:char(26);not null;index"`
+	Name       string     `json:"name" gorm:"not null"`
+	Prefix     string     `json:"prefix" gorm:"not null"`
+	TokenHash  string     `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes     []string   `json:"scopes" gorm:"type:jsonb;serializer:json;not null"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
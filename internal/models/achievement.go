@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+type UserAchievement struct {
+	ID        uint      `json:"id" gorm:"primary_key"`
+	UserID    string    `json:"user_id" gorm:"type:char(26);not null;uniqueIndex:idx_user_achievements_user_badge"`
+	BadgeKey  string    `json:"badge_key" gorm:"not null;uniqueIndex:idx_user_achievements_user_badge"`
+	SessionID *uint     `json:"session_id"`
+	AwardedAt time.Time `json:"awarded_at" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
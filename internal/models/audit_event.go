@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// AuditChange is a field's value before and after an audited change. Before is nil for fields of
+// a created record and After is nil for fields of a deleted one.
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditEvent records who changed what in a user's account. Events are append-only; they are
+// only removed along with the user they belong to.
+type AuditEvent struct {
+	ID         uint                   `json:"id" gorm:"primary_key"`
+	UserID     string                 `json:"-" gorm:"type:char(26);not null;index"`
+	ActorType  string                 `json:"actor_type" gorm:"not null"`
+	ActorID    string                 `json:"actor_id"`
+	Action     string                 `json:"action" gorm:"not null"`
+	TargetType string                 `json:"target_type" gorm:"not null"`
+	TargetID   string                 `json:"target_id" gorm:"not null"`
+	Changes    map[string]AuditChange `json:"changes" gorm:"type:jsonb;serializer:json;not null"`
+	RequestID  string                 `json:"request_id"`
+	IP         string                 `json:"ip"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
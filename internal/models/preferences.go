@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/constants"
+)
+
+// Days a user's week can start on
+const (
+	WeekStartMonday   = "monday"
+	WeekStartSaturday = "saturday"
+	WeekStartSunday   = "sunday"
+)
+
+// Unit systems used to display measurements such as walking distance
+const (
+	UnitsMetric   = "metric"
+	UnitsImperial = "imperial"
+)
+
+// UserPreferences holds a user's settings. Users who never saved any get DefaultPreferences,
+// so a row only exists once something was changed.
+type UserPreferences struct {
+	UserID string `json:"-" gorm:"type:char(26);primaryKey"`
+	// Timezone is an IANA name such as Europe/Berlin, used to decide which calendar day a
+	// session falls on
+	Timezone string `json:"timezone" gorm:"not null"`
+	// Locale is a BCP 47 language tag such as en-US
+	Locale    string `json:"locale" gorm:"not null"`
+	WeekStart string `json:"week_start" gorm:"not null"`
+	// The session type and duration clients preselect when starting a timer
+	DefaultSessionType     string `json:"default_session_type" gorm:"not null"`
+	DefaultDurationSeconds int    `json:"default_duration_seconds" gorm:"not null"`
+	Units                  string `json:"units" gorm:"not null"`
+	// RemindersEnabled opts the user in to practice reminders, StreakRemindersEnabled to being
+	// warned when a streak is about to break
+	RemindersEnabled       bool `json:"reminders_enabled" gorm:"not null"`
+	StreakRemindersEnabled bool `json:"streak_reminders_enabled" gorm:"not null"`
+	// QuietHoursStart and QuietHoursEnd are local HH:MM times between which no reminders are
+	// sent. The range may wrap past midnight; both are nil when the user has no quiet hours.
+	QuietHoursStart *string `json:"quiet_hours_start"`
+	QuietHoursEnd   *string `json:"quiet_hours_end"`
+	// ShareAnonymousUsage allows usage to be included in anonymised product statistics
+	ShareAnonymousUsage bool `json:"share_anonymous_usage" gorm:"not null"`
+	// Timestamps aren't serialised because defaults were never saved
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// DefaultPreferences returns the preferences of a user who hasn't changed any. Reminders and
+// usage sharing are opt-in.
+func DefaultPreferences(userID string) *UserPreferences {
+	return &UserPreferences{
+		UserID:                 userID,
+		Timezone:               "UTC",
+		Locale:                 "en-US",
+		WeekStart:              WeekStartMonday,
+		DefaultSessionType:     constants.SessionTypeMindfulness,
+		DefaultDurationSeconds: 600,
+		Units:                  UnitsMetric,
+	}
+}
+
+// Location returns the user's timezone, falling back to UTC if it can't be loaded
+func (p *UserPreferences) Location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// FirstWeekday returns the day the user's week starts on
+func (p *UserPreferences) FirstWeekday() time.Weekday {
+	switch p.WeekStart {
+	case WeekStartSunday:
+		return time.Sunday
+	case WeekStartSaturday:
+		return time.Saturday
+	default:
+		return time.Monday
+	}
+}
+
+// InQuietHours reports whether t, in the user's timezone, falls within their quiet hours
+func (p *UserPreferences) InQuietHours(t time.Time) bool {
+	if p.QuietHoursStart == nil || p.QuietHoursEnd == nil {
+		return false
+	}
+
+	// HH:MM strings compare in time order
+	now := t.Format("15:04")
+	start, end := *p.QuietHoursStart, *p.QuietHoursEnd
+	if start <= end {
+		return now >= start && now < end
+	}
+
+	return now >= start || now < end
+}
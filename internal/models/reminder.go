@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// Weekdays lists the day names reminders are scheduled on, indexed by time.Weekday
+var Weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// Reminder asks for a nudge to meditate at a local time of day on some days of the week. The
+// time is read in the user's timezone preference, so reminders follow the user when they move.
+type Reminder struct {
+	ID     uint   `json:"id" gorm:"primary_key"`
+	UserID string `json:"-" gorm:"type:char(26);not null;index"`
+	// Days holds names from Weekdays
+	Days []string `json:"days" gorm:"type:jsonb;serializer:json;not null"`
+	// LocalTime is the time of day as HH:MM
+	LocalTime string `json:"local_time" gorm:"not null"`
+	Enabled   bool   `json:"enabled" gorm:"not null"`
+	// LastTriggeredAt is when the scheduler last handled the reminder coming due, whether it
+	// sent a notification or skipped it, so each occurrence is only handled once
+	LastTriggeredAt *time.Time `json:"last_triggered_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// OnDay reports whether the reminder is scheduled on the given weekday
+func (r *Reminder) OnDay(day time.Weekday) bool {
+	for _, name := range r.Days {
+		if name == Weekdays[day] {
+			return true
+		}
+	}
+
+	return false
+}
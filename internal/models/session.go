@@ -7,15 +7,28 @@ import (
 )
 
 type Session struct {
-	ID              uint           `json:"id" gorm:"primary_key"`
-	UserID          string         `json:"user_id" gorm:"type:char(26);not null;index"`
-	DurationSeconds int            `json:"duration_seconds" gorm:"not null"`
-	SessionType     string         `json:"session_type" gorm:"not null"`
-	Notes           string         `json:"notes"`
-	CreatedAt       time.Time      `json:"created_at"`
-	UpdatedAt       time.Time      `json:"updated_at"`
-	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	ID              uint   `json:"id" gorm:"primary_key"`
+	UserID          string `json:"user_id" gorm:"type:char(26);not null;index"`
+	DurationSeconds int    `json:"duration_seconds" gorm:"not null"`
+	SessionType     string `json:"session_type" gorm:"not null"`
+	Notes           string `json:"notes"`
+	// Tags are the user's own labels for the session, such as "sleep" or "work", in lowercase
+	Tags []string `json:"tags" gorm:"type:jsonb;serializer:json;not null"`
+	// MoodBefore and MoodAfter rate how the user felt from 1 (low) to 5 (great); nil when not rated
+	MoodBefore *int           `json:"mood_before"`
+	MoodAfter  *int           `json:"mood_after"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 
 	// Relationships
 	User User `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
+
+func (s *Session) BeforeCreate(tx *gorm.DB) error {
+	if s.Tags == nil {
+		s.Tags = []string{}
+	}
+
+	return nil
+}
@@ -8,15 +8,30 @@ import (
 	"gorm.io/gorm"
 )
 
+// Roles a user can hold. Admins can use the /api/admin routes.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// IsValidRole reports whether role is one of the known roles
+func IsValidRole(role string) bool {
+	return role == RoleUser || role == RoleAdmin
+}
+
 type User struct {
 	ID          string         `json:"id" gorm:"type:char(26);primary_key"`
 	ClerkUserID string         `json:"clerk_user_id" gorm:"unique;not null"`
 	Email       string         `json:"email" gorm:"not null"`
 	FirstName   *string        `json:"first_name"`
 	LastName    *string        `json:"last_name"`
+	Role        string         `json:"role" gorm:"not null;default:user"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	// DeletionRequestedAt is when the user asked for their account to be deleted. The account is
+	// purged once the grace period has passed unless the request is cancelled first.
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at" gorm:"index"`
 
 	// Relationships
 	Sessions []Session `json:"sessions,omitempty" gorm:"foreignKey:UserID"`
@@ -26,6 +41,9 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	if u.ID == "" {
 		u.ID = ulid.MustNew(ulid.Timestamp(time.Now()), rand.Reader).String()
 	}
+	if u.Role == "" {
+		u.Role = RoleUser
+	}
 
 	return nil
 }
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// WebhookEvent records a verified webhook delivery and the outcome of processing it
+type WebhookEvent struct {
+	ID          uint       `json:"id" gorm:"primary_key"`
+	EventType   string     `json:"event_type" gorm:"not null"`
+	ClerkUserID string     `json:"clerk_user_id" gorm:"index"`
+	Payload     string     `json:"payload" gorm:"type:jsonb;not null"`
+	Error       string     `json:"error"`
+	ReceivedAt  time.Time  `json:"received_at" gorm:"not null"`
+	ProcessedAt *time.Time `json:"processed_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+// YearReview caches a generated year-in-review summary for a user
+type YearReview struct {
+	ID          uint      `json:"id" gorm:"primary_key"`
+	UserID      string    `json:"user_id" gorm:"type:char(26);not null;uniqueIndex:idx_year_reviews_user_year"`
+	Year        int       `json:"year" gorm:"not null;uniqueIndex:idx_year_reviews_user_year"`
+	Summary     string    `json:"summary" gorm:"type:jsonb;not null"`
+	GeneratedAt time.Time `json:"generated_at" gorm:"not null"`
+}
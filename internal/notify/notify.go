@@ -0,0 +1,46 @@
+// Package notify delivers notifications to users. The Dispatcher decides how they reach the
+// user, so the code deciding when to notify doesn't depend on a push or email provider.
+package notify
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Kinds of notification, so the delivery side can pick a template
+const (
+	KindReminder = "reminder"
+)
+
+// Notification is a message for one user. Title and Body are English; Locale lets a delivery
+// service that has translations use the user's language instead.
+type Notification struct {
+	UserID string `json:"user_id"`
+	Kind   string `json:"kind"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	Locale string `json:"locale"`
+	// ReminderID is the reminder that fired, for reminder notifications
+	ReminderID uint `json:"reminder_id,omitempty"`
+	// StreakDays is the streak at risk, for reminders sent to users who opted in to streak reminders
+	StreakDays int `json:"streak_days,omitempty"`
+}
+
+// Dispatcher delivers notifications
+type Dispatcher interface {
+	Dispatch(ctx context.Context, notification Notification) error
+}
+
+// LogDispatcher writes notifications to the log instead of delivering them. It is the default
+// when no delivery service is configured, e.g. in development.
+type LogDispatcher struct{}
+
+func (LogDispatcher) Dispatch(ctx context.Context, notification Notification) error {
+	slog.InfoContext(ctx, "notification",
+		slog.String("user_id", notification.UserID),
+		slog.String("kind", notification.Kind),
+		slog.String("title", notification.Title),
+		slog.String("body", notification.Body))
+
+	return nil
+}
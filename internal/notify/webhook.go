@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// webhookTimeout bounds each delivery so a slow service can't hold up the scheduler
+const webhookTimeout = 10 * time.Second
+
+// WebhookDispatcher POSTs each notification as JSON to a delivery service, such as a push
+// gateway, which sends it on to the user's devices
+type WebhookDispatcher struct {
+	url    string
+	secret string
+	http   *http.Client
+}
+
+// NewWebhookDispatcher returns a dispatcher posting to url. A non-empty secret is sent as a
+// bearer token so the service can reject other callers.
+func NewWebhookDispatcher(url, secret string) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		url:    url,
+		secret: secret,
+		http:   &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport), Timeout: webhookTimeout},
+	}
+}
+
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if d.secret != "" {
+		req.Header.Set("Authorization", "Bearer "+d.secret)
+	}
+
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
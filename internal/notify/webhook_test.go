@@ -0,0 +1,46 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/notify"
+)
+
+func TestWebhookDispatcher(t *testing.T) {
+	t.Run("post the notification as json with the secret", func(t *testing.T) {
+		var received notify.Notification
+		var authorization string
+		service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer service.Close()
+
+		dispatcher := notify.NewWebhookDispatcher(service.URL, "s3cret")
+		err := dispatcher.Dispatch(context.Background(), notify.Notification{UserID: "user_1", Kind: notify.KindReminder, Title: "Time to meditate", ReminderID: 4})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer s3cret", authorization)
+		assert.Equal(t, "user_1", received.UserID)
+		assert.Equal(t, uint(4), received.ReminderID)
+	})
+
+	t.Run("return error when the service rejects the notification", func(t *testing.T) {
+		service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer service.Close()
+
+		err := notify.NewWebhookDispatcher(service.URL, "").Dispatch(context.Background(), notify.Notification{UserID: "user_1"})
+
+		assert.ErrorContains(t, err, "502")
+	})
+}
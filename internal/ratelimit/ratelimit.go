@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/apierror"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/auth"
+)
+
+// Limit allows Requests per Period, refilled continuously, with bursts of up to Requests
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Enabled reports whether the limit restricts anything; a zero limit lets every request through
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// Result is the state of a bucket after taking a token from it
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until the next token is available; zero when Allowed
+	RetryAfter time.Duration
+}
+
+// Store holds token buckets by key. MemoryStore suits a single instance; replicas behind a load
+// balancer need a shared implementation (e.g. backed by Redis) so they enforce one limit between them.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Middleware limits requests per client within group. Clients are identified by user ID once
+// authenticated and by IP address otherwise. If the store fails the request is let through,
+// since an outage in a shared store shouldn't take the API down with it.
+func Middleware(store Store, group string, limit Limit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !limit.Enabled() {
+			c.Next()
+
+			return
+		}
+
+		result, err := store.Take(c.Request.Context(), group+":"+clientKey(c), limit)
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "rate limit store failed, allowing request",
+				slog.String("group", group), slog.String("error", err.Error()))
+			c.Next()
+
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+		c.Header("RateLimit-Policy", strconv.Itoa(limit.Requests)+";w="+strconv.Itoa(seconds(limit.Period)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(seconds(result.RetryAfter)))
+			apierror.Abort(c, apierror.New(http.StatusTooManyRequests, apierror.CodeRateLimited, "Too many requests, retry later"))
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func clientKey(c *gin.Context) string {
+	if userID := auth.GetCurrentUserID(c); userID != "" {
+		return "user:" + userID
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+// seconds rounds up so clients never retry before a token is actually available
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,139 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/ratelimit"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func TestMemoryStore(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+
+	t.Run("allow a burst up to the limit then reject", func(t *testing.T) {
+		now := time.Now()
+		store := ratelimit.NewMemoryStoreWithClock(func() time.Time { return now })
+
+		first, _ := store.Take(context.Background(), "client", limit)
+		second, _ := store.Take(context.Background(), "client", limit)
+		third, _ := store.Take(context.Background(), "client", limit)
+
+		assert.True(t, first.Allowed)
+		assert.Equal(t, 1, first.Remaining)
+		assert.True(t, second.Allowed)
+		assert.Equal(t, 0, second.Remaining)
+		assert.Equal(t, time.Minute, second.Reset)
+		assert.False(t, third.Allowed)
+		assert.Equal(t, 30*time.Second, third.RetryAfter)
+	})
+
+	t.Run("refill tokens over time", func(t *testing.T) {
+		now := time.Now()
+		store := ratelimit.NewMemoryStoreWithClock(func() time.Time { return now })
+		_, _ = store.Take(context.Background(), "client", limit)
+		_, _ = store.Take(context.Background(), "client", limit)
+
+		now = now.Add(30 * time.Second)
+		result, _ := store.Take(context.Background(), "client", limit)
+
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+	})
+
+	t.Run("keep separate buckets per key", func(t *testing.T) {
+		store := ratelimit.NewMemoryStore()
+		_, _ = store.Take(context.Background(), "first", limit)
+		_, _ = store.Take(context.Background(), "first", limit)
+
+		result, _ := store.Take(context.Background(), "second", limit)
+
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1, result.Remaining)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(store ratelimit.Store, limit ratelimit.Limit) *gin.Engine {
+		router := gin.New()
+		router.GET("/ping", ratelimit.Middleware(store, "public", limit), func(c *gin.Context) {
+			c.String(http.StatusOK, "pong")
+		})
+
+		return router
+	}
+	request := func(router *gin.Engine, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/ping", nil)
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("set rate limit headers on allowed requests", func(t *testing.T) {
+		router := newRouter(ratelimit.NewMemoryStore(), ratelimit.Limit{Requests: 5, Period: time.Minute})
+
+		w := request(router, "192.0.2.1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "5", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "4", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "12", w.Header().Get("RateLimit-Reset"))
+		assert.Equal(t, "5;w=60", w.Header().Get("RateLimit-Policy"))
+		assert.Empty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("return too many requests with retry after when exhausted", func(t *testing.T) {
+		router := newRouter(ratelimit.NewMemoryStore(), ratelimit.Limit{Requests: 1, Period: time.Minute})
+		request(router, "192.0.2.1")
+
+		w := request(router, "192.0.2.1")
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+		assert.Contains(t, w.Body.String(), "RATE_LIMITED")
+	})
+
+	t.Run("limit each client ip separately", func(t *testing.T) {
+		router := newRouter(ratelimit.NewMemoryStore(), ratelimit.Limit{Requests: 1, Period: time.Minute})
+		request(router, "192.0.2.1")
+
+		w := request(router, "192.0.2.2")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("allow every request when the limit is disabled", func(t *testing.T) {
+		router := newRouter(ratelimit.NewMemoryStore(), ratelimit.Limit{})
+		request(router, "192.0.2.1")
+
+		w := request(router, "192.0.2.1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	})
+
+	t.Run("allow requests when the store fails", func(t *testing.T) {
+		router := newRouter(failingStore{}, ratelimit.Limit{Requests: 1, Period: time.Minute})
+
+		w := request(router, "192.0.2.1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,154 @@
+// Package cache wraps repositories with short-lived in-process caches for hot lookups
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+// UserRepository caches FindByClerkUserID, which authentication calls on every request. Writes
+// through this repository, such as the Clerk webhook's updates and deletes, evict the user at
+// once; writes made elsewhere (another replica, mindctl) are picked up when the entry expires.
+type UserRepository struct {
+	repository.UserRepository
+
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]entry
+	lastSweep time.Time
+	// generation counts evictions, so a lookup that raced with a write doesn't store what it read
+	generation uint64
+}
+
+type entry struct {
+	user    models.User
+	expires time.Time
+}
+
+// NewUserRepository caches users from users for ttl
+func NewUserRepository(users repository.UserRepository, ttl time.Duration) *UserRepository {
+	return NewUserRepositoryWithClock(users, ttl, time.Now)
+}
+
+// NewUserRepositoryWithClock returns a cache that reads the time from now, for tests that control time
+func NewUserRepositoryWithClock(users repository.UserRepository, ttl time.Duration, now func() time.Time) *UserRepository {
+	return &UserRepository{
+		UserRepository: users,
+		ttl:            ttl,
+		now:            now,
+		entries:        make(map[string]entry),
+		lastSweep:      now(),
+	}
+}
+
+// FindByClerkUserID returns a cached copy of the user when one hasn't expired. Misses aren't
+// cached, so a user created by the webhook is found on its next request.
+func (r *UserRepository) FindByClerkUserID(ctx context.Context, clerkUserID string) (*models.User, error) {
+	r.mu.Lock()
+	e, ok := r.entries[clerkUserID]
+	if ok && r.now().Before(e.expires) {
+		r.mu.Unlock()
+		metrics.UserCacheLookup("hit")
+
+		return &e.user, nil
+	}
+	delete(r.entries, clerkUserID)
+	generation := r.generation
+	r.mu.Unlock()
+	metrics.UserCacheLookup("miss")
+
+	user, err := r.UserRepository.FindByClerkUserID(ctx, clerkUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A write that finished while the user was being read may have been missed, so only store
+	// the user if nothing was evicted in the meantime
+	r.mu.Lock()
+	r.sweep()
+	if r.generation == generation {
+		r.entries[clerkUserID] = entry{user: *user, expires: r.now().Add(r.ttl)}
+	}
+	r.mu.Unlock()
+
+	return user, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	err := r.UserRepository.Update(ctx, user)
+	r.evict(user.ID)
+
+	return err
+}
+
+func (r *UserRepository) SetDeletionRequestedAt(ctx context.Context, id string, at *time.Time) error {
+	err := r.UserRepository.SetDeletionRequestedAt(ctx, id, at)
+	r.evict(id)
+
+	return err
+}
+
+func (r *UserRepository) DeleteByClerkUserID(ctx context.Context, clerkUserID string) error {
+	err := r.UserRepository.DeleteByClerkUserID(ctx, clerkUserID)
+
+	r.mu.Lock()
+	delete(r.entries, clerkUserID)
+	r.generation++
+	r.mu.Unlock()
+
+	return err
+}
+
+func (r *UserRepository) Restore(ctx context.Context, id string) (*models.User, error) {
+	user, err := r.UserRepository.Restore(ctx, id)
+	r.evict(id)
+
+	return user, err
+}
+
+func (r *UserRepository) HardDelete(ctx context.Context, id string) error {
+	err := r.UserRepository.HardDelete(ctx, id)
+	r.evict(id)
+
+	return err
+}
+
+// evict drops the user with the given ID once a write has completed. Entries are keyed by Clerk
+// user ID, which an update may change, so they are matched on the user's ID instead.
+func (r *UserRepository) evict(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+
+	for key, e := range r.entries {
+		if e.user.ID == id {
+			delete(r.entries, key)
+		}
+	}
+}
+
+// sweep drops expired entries at most once per TTL, so users who stopped making requests don't
+// accumulate. The caller holds mu.
+func (r *UserRepository) sweep() {
+	now := r.now()
+	if now.Sub(r.lastSweep) < r.ttl {
+		return
+	}
+	r.lastSweep = now
+
+	for key, e := range r.entries {
+		if !now.Before(e.expires) {
+			delete(r.entries, key)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/cache"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+)
+
+// racingUsers runs during once, after reading a user but before returning it, like a write that
+// lands while a lookup is in flight
+type racingUsers struct {
+	repository.UserRepository
+
+	during func()
+}
+
+func (r *racingUsers) FindByClerkUserID(ctx context.Context, clerkUserID string) (*models.User, error) {
+	user, err := r.UserRepository.FindByClerkUserID(ctx, clerkUserID)
+	if during := r.during; during != nil {
+		r.during = nil
+		during()
+	}
+
+	return user, err
+}
+
+func TestUserRepository(t *testing.T) {
+	ctx := context.Background()
+
+	// setup returns a cache over a memory repository holding one user, with a clock the test moves
+	setup := func() (*cache.UserRepository, repository.UserRepository, *time.Time) {
+		inner := memory.NewRepositories().Users
+		require.NoError(t, inner.Create(ctx, testutils.CreateTestUser("user_123")))
+
+		now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+		users := cache.NewUserRepositoryWithClock(inner, 30*time.Second, func() time.Time { return now })
+
+		return users, inner, &now
+	}
+
+	// renameBehindCache changes the stored user without going through the cache
+	renameBehindCache := func(t *testing.T, inner repository.UserRepository, email string) {
+		t.Helper()
+
+		user, err := inner.FindByClerkUserID(ctx, "user_123")
+		require.NoError(t, err)
+		user.Email = email
+		require.NoError(t, inner.Update(ctx, user))
+	}
+
+	t.Run("return cached user until it expires", func(t *testing.T) {
+		users, inner, now := setup()
+		_, err := users.FindByClerkUserID(ctx, "user_123")
+		require.NoError(t, err)
+
+		renameBehindCache(t, inner, "new@example.com")
+
+		user, err := users.FindByClerkUserID(ctx, "user_123")
+		require.NoError(t, err)
+		assert.Equal(t, "test@example.com", user.Email)
+
+		*now = now.Add(30 * time.Second)
+		user, err = users.FindByClerkUserID(ctx, "user_123")
+		require.NoError(t, err)
+		assert.Equal(t, "new@example.com", user.Email)
+	})
+
+	t.Run("return a copy callers can modify", func(t *testing.T) {
+		users, _, _ := setup()
+		user, _ := users.FindByClerkUserID(ctx, "user_123")
+		user.Email = "changed@example.com"
+
+		user, err := users.FindByClerkUserID(ctx, "user_123")
+
+		require.NoError(t, err)
+		assert.Equal(t, "test@example.com", user.Email)
+	})
+
+	t.Run("evict user when it is updated", func(t *testing.T) {
+		users, _, _ := setup()
+		user, _ := users.FindByClerkUserID(ctx, "user_123")
+		user.Email = "updated@example.com"
+		require.NoError(t, users.Update(ctx, user))
+
+		user, err := users.FindByClerkUserID(ctx, "user_123")
+
+		require.NoError(t, err)
+		assert.Equal(t, "updated@example.com", user.Email)
+	})
+
+	t.Run("evict user when it is deleted", func(t *testing.T) {
+		users, _, _ := setup()
+		_, _ = users.FindByClerkUserID(ctx, "user_123")
+		require.NoError(t, users.DeleteByClerkUserID(ctx, "user_123"))
+
+		_, err := users.FindByClerkUserID(ctx, "user_123")
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("evict user when it is hard deleted", func(t *testing.T) {
+		users, _, _ := setup()
+		user, _ := users.FindByClerkUserID(ctx, "user_123")
+		require.NoError(t, users.HardDelete(ctx, user.ID))
+
+		_, err := users.FindByClerkUserID(ctx, "user_123")
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("find user created after a miss", func(t *testing.T) {
+		users, _, _ := setup()
+		_, err := users.FindByClerkUserID(ctx, "user_456")
+		require.ErrorIs(t, err, repository.ErrNotFound)
+
+		require.NoError(t, users.Create(ctx, testutils.CreateTestUser("user_456")))
+		user, err := users.FindByClerkUserID(ctx, "user_456")
+
+		require.NoError(t, err)
+		assert.Equal(t, "user_456", user.ClerkUserID)
+	})
+
+	t.Run("evict user when it is restored", func(t *testing.T) {
+		users, inner, _ := setup()
+		user, _ := inner.FindByClerkUserID(ctx, "user_123")
+		requestedAt := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+		user.DeletionRequestedAt = &requestedAt
+		require.NoError(t, inner.Update(ctx, user))
+		_, _ = users.FindByClerkUserID(ctx, "user_123")
+
+		require.NoError(t, inner.DeleteByClerkUserID(ctx, "user_123"))
+		_, err := users.Restore(ctx, user.ID)
+		require.NoError(t, err)
+
+		user, err = users.FindByClerkUserID(ctx, "user_123")
+		require.NoError(t, err)
+		assert.Nil(t, user.DeletionRequestedAt)
+	})
+
+	t.Run("don't store a user read before a concurrent update", func(t *testing.T) {
+		inner := &racingUsers{UserRepository: memory.NewRepositories().Users}
+		require.NoError(t, inner.Create(ctx, testutils.CreateTestUser("user_123")))
+		users := cache.NewUserRepository(inner, time.Minute)
+		inner.during = func() {
+			user, err := inner.UserRepository.FindByClerkUserID(ctx, "user_123")
+			require.NoError(t, err)
+			user.Email = "updated@example.com"
+			require.NoError(t, users.Update(ctx, user))
+		}
+
+		stale, err := users.FindByClerkUserID(ctx, "user_123")
+		require.NoError(t, err)
+		assert.Equal(t, "test@example.com", stale.Email)
+
+		user, err := users.FindByClerkUserID(ctx, "user_123")
+		require.NoError(t, err)
+		assert.Equal(t, "updated@example.com", user.Email)
+	})
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type AccessTokenRepository struct {
+	store *store
+}
+
+func (r *AccessTokenRepository) Create(_ context.Context, token *models.AccessToken) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.accessTokens {
+		if existing.TokenHash == token.TokenHash {
+			return repository.ErrDuplicate
+		}
+	}
+
+	r.store.nextAccessTokenID++
+	token.ID = r.store.nextAccessTokenID
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+	r.store.accessTokens = append(r.store.accessTokens, *token)
+
+	return nil
+}
+
+func (r *AccessTokenRepository) FindByHash(_ context.Context, hash string) (*models.AccessToken, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, token := range r.store.accessTokens {
+		if token.TokenHash == hash {
+			return &token, nil
+		}
+	}
+
+	return nil, repository.ErrNotFound
+}
+
+func (r *AccessTokenRepository) ListForUser(_ context.Context, userID string) ([]models.AccessToken, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var tokens []models.AccessToken
+	for _, token := range r.store.accessTokens {
+		if token.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].ID > tokens[j].ID })
+
+	return tokens, nil
+}
+
+func (r *AccessTokenRepository) Delete(_ context.Context, userID string, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i, token := range r.store.accessTokens {
+		if token.UserID == userID && token.ID == id {
+			r.store.accessTokens = append(r.store.accessTokens[:i], r.store.accessTokens[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return repository.ErrNotFound
+}
+
+func (r *AccessTokenRepository) MarkUsed(_ context.Context, id uint, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := range r.store.accessTokens {
+		if r.store.accessTokens[i].ID == id {
+			r.store.accessTokens[i].LastUsedAt = &at
+
+			return nil
+		}
+	}
+
+	return repository.ErrNotFound
+}
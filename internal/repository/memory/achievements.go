@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+)
+
+type AchievementRepository struct {
+	store *store
+}
+
+func (r *AchievementRepository) ListForUser(_ context.Context, userID string) ([]models.UserAchievement, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var achievements []models.UserAchievement
+	for _, achievement := range r.store.achievements {
+		if achievement.UserID == userID {
+			achievements = append(achievements, achievement)
+		}
+	}
+	sort.SliceStable(achievements, func(i, j int) bool { return achievements[i].AwardedAt.Before(achievements[j].AwardedAt) })
+
+	return achievements, nil
+}
+
+func (r *AchievementRepository) Award(_ context.Context, achievement *models.UserAchievement) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.achievements {
+		if existing.UserID == achievement.UserID && existing.BadgeKey == achievement.BadgeKey {
+			return false, nil
+		}
+	}
+
+	r.store.nextAchievementID++
+	achievement.ID = r.store.nextAchievementID
+	if achievement.CreatedAt.IsZero() {
+		achievement.CreatedAt = time.Now()
+	}
+	r.store.achievements = append(r.store.achievements, *achievement)
+
+	return true, nil
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+)
+
+type AuditEventRepository struct {
+	store *store
+}
+
+func (r *AuditEventRepository) Create(_ context.Context, event *models.AuditEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.nextAuditEventID++
+	event.ID = r.store.nextAuditEventID
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	r.store.auditEvents = append(r.store.auditEvents, *event)
+
+	return nil
+}
+
+func (r *AuditEventRepository) ListForUser(_ context.Context, userID string, beforeID uint, limit int) ([]models.AuditEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var events []models.AuditEvent
+	for _, event := range r.store.auditEvents {
+		if event.UserID == userID && (beforeID == 0 || event.ID < beforeID) {
+			events = append(events, event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID > events[j].ID })
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
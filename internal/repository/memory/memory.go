@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+// Compile-time checks that every repository satisfies its interface
+var (
+	_ repository.UserRepository         = (*UserRepository)(nil)
+	_ repository.SessionRepository      = (*SessionRepository)(nil)
+	_ repository.AchievementRepository  = (*AchievementRepository)(nil)
+	_ repository.YearReviewRepository   = (*YearReviewRepository)(nil)
+	_ repository.WebhookEventRepository = (*WebhookEventRepository)(nil)
+	_ repository.AccessTokenRepository  = (*AccessTokenRepository)(nil)
+	_ repository.AuditEventRepository   = (*AuditEventRepository)(nil)
+	_ repository.PreferencesRepository  = (*PreferencesRepository)(nil)
+	_ repository.ReminderRepository     = (*ReminderRepository)(nil)
+	_ repository.StatsRepository        = (*StatsRepository)(nil)
+)
+
+// store is the shared state behind the in-memory repositories
+type store struct {
+	mu sync.RWMutex
+
+	users         map[string]models.User
+	sessions      []models.Session
+	achievements  []models.UserAchievement
+	yearReviews   []models.YearReview
+	webhookEvents []models.WebhookEvent
+	accessTokens  []models.AccessToken
+	auditEvents   []models.AuditEvent
+	preferences   map[string]models.UserPreferences
+	reminders     []models.Reminder
+
+	nextSessionID      uint
+	nextAchievementID  uint
+	nextYearReviewID   uint
+	nextWebhookEventID uint
+	nextAccessTokenID  uint
+	nextAuditEventID   uint
+	nextReminderID     uint
+}
+
+// NewRepositories returns in-memory implementations of every repository sharing one store.
+// They are intended for tests and local experiments, nothing is persisted.
+func NewRepositories() *repository.Repositories {
+	s := &store{users: make(map[string]models.User), preferences: make(map[string]models.UserPreferences)}
+
+	return &repository.Repositories{
+		Users:         &UserRepository{store: s},
+		Sessions:      &SessionRepository{store: s},
+		Achievements:  &AchievementRepository{store: s},
+		YearReviews:   &YearReviewRepository{store: s},
+		WebhookEvents: &WebhookEventRepository{store: s},
+		AccessTokens:  &AccessTokenRepository{store: s},
+		AuditEvents:   &AuditEventRepository{store: s},
+		Preferences:   &PreferencesRepository{store: s},
+		Reminders:     &ReminderRepository{store: s},
+		Stats:         &StatsRepository{store: s},
+	}
+}
+
+// inRange reports whether t falls in [from, to), ignoring zero bounds
+func inRange(t, from, to time.Time) bool {
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+
+	if !to.IsZero() && !t.Before(to) {
+		return false
+	}
+
+	return true
+}
+
+// touch fills in creation and update timestamps the way GORM does on create
+func touch(createdAt, updatedAt *time.Time) {
+	now := time.Now()
+	if createdAt.IsZero() {
+		*createdAt = now
+	}
+
+	if updatedAt.IsZero() {
+		*updatedAt = now
+	}
+}
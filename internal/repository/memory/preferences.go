@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+)
+
+type PreferencesRepository struct {
+	store *store
+}
+
+func (r *PreferencesRepository) Get(_ context.Context, userID string) (*models.UserPreferences, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	preferences, exists := r.store.preferences[userID]
+	if !exists {
+		return models.DefaultPreferences(userID), nil
+	}
+
+	return &preferences, nil
+}
+
+func (r *PreferencesRepository) Save(_ context.Context, preferences *models.UserPreferences) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if existing, exists := r.store.preferences[preferences.UserID]; exists {
+		preferences.CreatedAt = existing.CreatedAt
+	}
+	touch(&preferences.CreatedAt, &preferences.UpdatedAt)
+	preferences.UpdatedAt = time.Now()
+	r.store.preferences[preferences.UserID] = *preferences
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type ReminderRepository struct {
+	store *store
+}
+
+func (r *ReminderRepository) Create(_ context.Context, reminder *models.Reminder) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.nextReminderID++
+	reminder.ID = r.store.nextReminderID
+	touch(&reminder.CreatedAt, &reminder.UpdatedAt)
+	r.store.reminders = append(r.store.reminders, *reminder)
+
+	return nil
+}
+
+func (r *ReminderRepository) Update(_ context.Context, reminder *models.Reminder) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := range r.store.reminders {
+		if r.store.reminders[i].ID == reminder.ID {
+			reminder.UpdatedAt = time.Now()
+			r.store.reminders[i] = *reminder
+
+			return nil
+		}
+	}
+
+	return repository.ErrNotFound
+}
+
+func (r *ReminderRepository) FindForUser(_ context.Context, userID string, id uint) (*models.Reminder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, reminder := range r.store.reminders {
+		if reminder.UserID == userID && reminder.ID == id {
+			return &reminder, nil
+		}
+	}
+
+	return nil, repository.ErrNotFound
+}
+
+func (r *ReminderRepository) ListForUser(_ context.Context, userID string) ([]models.Reminder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var reminders []models.Reminder
+	for _, reminder := range r.store.reminders {
+		if reminder.UserID == userID {
+			reminders = append(reminders, reminder)
+		}
+	}
+	sortReminders(reminders)
+
+	return reminders, nil
+}
+
+func (r *ReminderRepository) Delete(_ context.Context, userID string, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i, reminder := range r.store.reminders {
+		if reminder.UserID == userID && reminder.ID == id {
+			r.store.reminders = append(r.store.reminders[:i], r.store.reminders[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return repository.ErrNotFound
+}
+
+func (r *ReminderRepository) ListEnabled(_ context.Context) ([]models.Reminder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var reminders []models.Reminder
+	for _, reminder := range r.store.reminders {
+		if user, exists := r.store.users[reminder.UserID]; reminder.Enabled && exists && !user.DeletedAt.Valid {
+			reminders = append(reminders, reminder)
+		}
+	}
+	sortReminders(reminders)
+
+	return reminders, nil
+}
+
+func (r *ReminderRepository) MarkTriggered(_ context.Context, id uint, dueAt, at time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := range r.store.reminders {
+		if r.store.reminders[i].ID != id {
+			continue
+		}
+
+		if last := r.store.reminders[i].LastTriggeredAt; last != nil && !last.Before(dueAt) {
+			return false, nil
+		}
+		r.store.reminders[i].LastTriggeredAt = &at
+
+		return true, nil
+	}
+
+	return false, repository.ErrNotFound
+}
+
+// sortReminders orders reminders by time of day, then by ID, as the Postgres queries do
+func sortReminders(reminders []models.Reminder) {
+	sort.Slice(reminders, func(i, j int) bool {
+		if reminders[i].LocalTime != reminders[j].LocalTime {
+			return reminders[i].LocalTime < reminders[j].LocalTime
+		}
+
+		return reminders[i].ID < reminders[j].ID
+	})
+}
@@ -0,0 +1,271 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type SessionRepository struct {
+	store *store
+}
+
+func (r *SessionRepository) Create(_ context.Context, session *models.Session) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.nextSessionID++
+	session.ID = r.store.nextSessionID
+	if session.Tags == nil {
+		session.Tags = []string{}
+	}
+	touch(&session.CreatedAt, &session.UpdatedAt)
+	r.store.sessions = append(r.store.sessions, *session)
+
+	return nil
+}
+
+func (r *SessionRepository) FindForUser(_ context.Context, userID string, id uint) (*models.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, session := range r.store.sessions {
+		if session.ID == id && session.UserID == userID && !session.DeletedAt.Valid {
+			return &session, nil
+		}
+	}
+
+	return nil, repository.ErrNotFound
+}
+
+// Delete soft deletes the session
+func (r *SessionRepository) Delete(_ context.Context, session *models.Session) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := range r.store.sessions {
+		if r.store.sessions[i].ID == session.ID {
+			r.store.sessions[i].DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			session.DeletedAt = r.store.sessions[i].DeletedAt
+		}
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) ListBefore(_ context.Context, userID string, beforeID uint, limit int) ([]models.Session, error) {
+	sessions := r.userSessions(userID, time.Time{}, time.Time{})
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID > sessions[j].ID })
+
+	var result []models.Session
+	for _, session := range sessions {
+		if beforeID > 0 && session.ID >= beforeID {
+			continue
+		}
+
+		if len(result) == limit {
+			break
+		}
+
+		result = append(result, session)
+	}
+
+	return result, nil
+}
+
+func (r *SessionRepository) Recent(_ context.Context, userID string, limit int) ([]models.Session, error) {
+	sessions := r.userSessions(userID, time.Time{}, time.Time{})
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].CreatedAt.After(sessions[j].CreatedAt) })
+
+	if len(sessions) > limit {
+		sessions = sessions[:limit]
+	}
+
+	return sessions, nil
+}
+
+func (r *SessionRepository) Longest(_ context.Context, userID string, from, to time.Time) (*models.Session, error) {
+	sessions := r.userSessions(userID, from, to)
+	if len(sessions) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	longest := sessions[0]
+	for _, session := range sessions[1:] {
+		if session.DurationSeconds > longest.DurationSeconds ||
+			(session.DurationSeconds == longest.DurationSeconds && session.CreatedAt.Before(longest.CreatedAt)) {
+			longest = session
+		}
+	}
+
+	return &longest, nil
+}
+
+func (r *SessionRepository) DailyTotals(_ context.Context, userID string, from, to time.Time, loc *time.Location) ([]repository.DailyTotal, error) {
+	byDay := make(map[time.Time]*repository.DailyTotal)
+	for _, session := range r.userSessions(userID, from, to) {
+		created := session.CreatedAt.In(loc)
+		day := time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.UTC)
+
+		total, exists := byDay[day]
+		if !exists {
+			total = &repository.DailyTotal{Day: day}
+			byDay[day] = total
+		}
+
+		total.Seconds += session.DurationSeconds
+		total.Sessions++
+	}
+
+	totals := make([]repository.DailyTotal, 0, len(byDay))
+	for _, total := range byDay {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Day.Before(totals[j].Day) })
+
+	return totals, nil
+}
+
+func (r *SessionRepository) TypeTotals(_ context.Context, userID string, from, to time.Time) ([]repository.TypeTotal, error) {
+	byType := make(map[string]*repository.TypeTotal)
+	for _, session := range r.userSessions(userID, from, to) {
+		total, exists := byType[session.SessionType]
+		if !exists {
+			total = &repository.TypeTotal{SessionType: session.SessionType}
+			byType[session.SessionType] = total
+		}
+
+		total.Seconds += session.DurationSeconds
+		total.Sessions++
+	}
+
+	totals := make([]repository.TypeTotal, 0, len(byType))
+	for _, total := range byType {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Sessions != totals[j].Sessions {
+			return totals[i].Sessions > totals[j].Sessions
+		}
+
+		if totals[i].Seconds != totals[j].Seconds {
+			return totals[i].Seconds > totals[j].Seconds
+		}
+
+		return totals[i].SessionType < totals[j].SessionType
+	})
+
+	return totals, nil
+}
+
+func (r *SessionRepository) TagTotals(_ context.Context, userID string, from, to time.Time) ([]repository.TagTotal, error) {
+	byTag := make(map[string]*repository.TagTotal)
+	for _, session := range r.userSessions(userID, from, to) {
+		for _, tag := range session.Tags {
+			total, exists := byTag[tag]
+			if !exists {
+				total = &repository.TagTotal{Tag: tag}
+				byTag[tag] = total
+			}
+
+			total.Seconds += session.DurationSeconds
+			total.Sessions++
+		}
+	}
+
+	totals := make([]repository.TagTotal, 0, len(byTag))
+	for _, total := range byTag {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Sessions != totals[j].Sessions {
+			return totals[i].Sessions > totals[j].Sessions
+		}
+
+		if totals[i].Seconds != totals[j].Seconds {
+			return totals[i].Seconds > totals[j].Seconds
+		}
+
+		return totals[i].Tag < totals[j].Tag
+	})
+
+	return totals, nil
+}
+
+func (r *SessionRepository) MoodTotals(_ context.Context, userID string, from, to time.Time) (repository.MoodTotals, error) {
+	var totals repository.MoodTotals
+	for _, session := range r.userSessions(userID, from, to) {
+		if session.MoodBefore == nil || session.MoodAfter == nil {
+			continue
+		}
+
+		totals.Sessions++
+		totals.MoodBefore += *session.MoodBefore
+		totals.MoodAfter += *session.MoodAfter
+	}
+
+	return totals, nil
+}
+
+func (r *SessionRepository) CountStartedBeforeHour(_ context.Context, userID string, hour int, loc *time.Location) (int, error) {
+	var count int
+	for _, session := range r.userSessions(userID, time.Time{}, time.Time{}) {
+		if session.CreatedAt.In(loc).Hour() < hour {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// userSessions returns copies of the user's live sessions created within the range
+func (r *SessionRepository) userSessions(userID string, from, to time.Time) []models.Session {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var sessions []models.Session
+	for _, session := range r.store.sessions {
+		if session.UserID == userID && !session.DeletedAt.Valid && inRange(session.CreatedAt, from, to) {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions
+}
+
+func (r *SessionRepository) ListWithDeleted(_ context.Context, userID string) ([]models.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var sessions []models.Session
+	for _, session := range r.store.sessions {
+		if session.UserID == userID {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID > sessions[j].ID })
+
+	return sessions, nil
+}
+
+func (r *SessionRepository) Restore(_ context.Context, userID string, id uint) (*models.Session, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := range r.store.sessions {
+		session := &r.store.sessions[i]
+		if session.ID == id && session.UserID == userID && session.DeletedAt.Valid {
+			session.DeletedAt = gorm.DeletedAt{}
+			restored := *session
+
+			return &restored, nil
+		}
+	}
+
+	return nil, repository.ErrNotFound
+}
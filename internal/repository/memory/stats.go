@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type StatsRepository struct {
+	store *store
+}
+
+func (r *StatsRepository) Totals(_ context.Context) (repository.Totals, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var totals repository.Totals
+	for _, user := range r.store.users {
+		if user.DeletedAt.Valid {
+			totals.DeletedUsers++
+		} else {
+			totals.Users++
+		}
+	}
+
+	for _, session := range r.store.sessions {
+		if session.DeletedAt.Valid {
+			totals.DeletedSessions++
+
+			continue
+		}
+
+		totals.Sessions++
+		totals.TotalSeconds += session.DurationSeconds
+	}
+
+	totals.Achievements = len(r.store.achievements)
+	totals.WebhookEvents = len(r.store.webhookEvents)
+
+	return totals, nil
+}
@@ -0,0 +1,243 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type UserRepository struct {
+	store *store
+}
+
+func (r *UserRepository) Create(_ context.Context, user *models.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if user.ID == "" {
+		user.ID = ulid.Make().String()
+	}
+
+	if _, exists := r.store.users[user.ID]; exists {
+		return repository.ErrDuplicate
+	}
+
+	for _, existing := range r.store.users {
+		if existing.ClerkUserID == user.ClerkUserID {
+			return repository.ErrDuplicate
+		}
+	}
+
+	if user.Role == "" {
+		user.Role = models.RoleUser
+	}
+	touch(&user.CreatedAt, &user.UpdatedAt)
+	r.store.users[user.ID] = *user
+
+	return nil
+}
+
+func (r *UserRepository) Update(_ context.Context, user *models.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.users[user.ID]; !exists {
+		return repository.ErrNotFound
+	}
+
+	user.UpdatedAt = time.Now()
+	r.store.users[user.ID] = *user
+
+	return nil
+}
+
+func (r *UserRepository) FindByID(_ context.Context, id string) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	user, exists := r.store.users[id]
+	if !exists || user.DeletedAt.Valid {
+		return nil, repository.ErrNotFound
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) FindByClerkUserID(_ context.Context, clerkUserID string) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, user := range r.store.users {
+		if user.ClerkUserID == clerkUserID && !user.DeletedAt.Valid {
+			return &user, nil
+		}
+	}
+
+	return nil, repository.ErrNotFound
+}
+
+// DeleteByClerkUserID soft deletes the user
+func (r *UserRepository) DeleteByClerkUserID(_ context.Context, clerkUserID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, user := range r.store.users {
+		if user.ClerkUserID == clerkUserID && !user.DeletedAt.Valid {
+			user.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			r.store.users[id] = user
+		}
+	}
+
+	return nil
+}
+
+func (r *UserRepository) Restore(_ context.Context, id string) (*models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	user, exists := r.store.users[id]
+	if !exists || !user.DeletedAt.Valid {
+		return nil, repository.ErrNotFound
+	}
+	user.DeletedAt = gorm.DeletedAt{}
+	user.DeletionRequestedAt = nil
+	r.store.users[id] = user
+
+	return &user, nil
+}
+
+func (r *UserRepository) SetDeletionRequestedAt(_ context.Context, id string, at *time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	user, exists := r.store.users[id]
+	if !exists || user.DeletedAt.Valid {
+		return repository.ErrNotFound
+	}
+	user.DeletionRequestedAt = at
+	user.UpdatedAt = time.Now()
+	r.store.users[id] = user
+
+	return nil
+}
+
+func (r *UserRepository) Search(_ context.Context, search repository.UserSearch) ([]models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var users []models.User
+	for _, user := range r.store.users {
+		if search.ID != "" && user.ID != search.ID {
+			continue
+		}
+
+		if search.Email != "" && !strings.EqualFold(user.Email, search.Email) {
+			continue
+		}
+
+		if search.ClerkUserID != "" && user.ClerkUserID != search.ClerkUserID {
+			continue
+		}
+
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].CreatedAt.Before(users[j].CreatedAt) })
+
+	return users, nil
+}
+
+func (r *UserRepository) ListDueForPurge(_ context.Context, cutoff time.Time) ([]models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var users []models.User
+	for _, user := range r.store.users {
+		requested := user.DeletionRequestedAt != nil && user.DeletionRequestedAt.Before(cutoff)
+		deleted := user.DeletedAt.Valid && user.DeletedAt.Time.Before(cutoff)
+		if requested || deleted {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].CreatedAt.Before(users[j].CreatedAt) })
+
+	return users, nil
+}
+
+// HardDelete removes the user and everything that would cascade from the users table in Postgres
+func (r *UserRepository) HardDelete(_ context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	user, exists := r.store.users[id]
+	if !exists {
+		return repository.ErrNotFound
+	}
+	delete(r.store.users, id)
+
+	sessions := r.store.sessions[:0]
+	for _, session := range r.store.sessions {
+		if session.UserID != id {
+			sessions = append(sessions, session)
+		}
+	}
+	r.store.sessions = sessions
+
+	achievements := r.store.achievements[:0]
+	for _, achievement := range r.store.achievements {
+		if achievement.UserID != id {
+			achievements = append(achievements, achievement)
+		}
+	}
+	r.store.achievements = achievements
+
+	reviews := r.store.yearReviews[:0]
+	for _, review := range r.store.yearReviews {
+		if review.UserID != id {
+			reviews = append(reviews, review)
+		}
+	}
+	r.store.yearReviews = reviews
+
+	tokens := r.store.accessTokens[:0]
+	for _, token := range r.store.accessTokens {
+		if token.UserID != id {
+			tokens = append(tokens, token)
+		}
+	}
+	r.store.accessTokens = tokens
+
+	auditEvents := r.store.auditEvents[:0]
+	for _, event := range r.store.auditEvents {
+		if event.UserID != id {
+			auditEvents = append(auditEvents, event)
+		}
+	}
+	r.store.auditEvents = auditEvents
+
+	delete(r.store.preferences, id)
+
+	reminders := r.store.reminders[:0]
+	for _, reminder := range r.store.reminders {
+		if reminder.UserID != id {
+			reminders = append(reminders, reminder)
+		}
+	}
+	r.store.reminders = reminders
+
+	events := r.store.webhookEvents[:0]
+	for _, event := range r.store.webhookEvents {
+		if event.ClerkUserID != user.ClerkUserID {
+			events = append(events, event)
+		}
+	}
+	r.store.webhookEvents = events
+
+	return nil
+}
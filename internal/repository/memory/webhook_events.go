@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type WebhookEventRepository struct {
+	store *store
+}
+
+func (r *WebhookEventRepository) Create(_ context.Context, event *models.WebhookEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.nextWebhookEventID++
+	event.ID = r.store.nextWebhookEventID
+	r.store.webhookEvents = append(r.store.webhookEvents, *event)
+
+	return nil
+}
+
+func (r *WebhookEventRepository) Update(_ context.Context, event *models.WebhookEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := range r.store.webhookEvents {
+		if r.store.webhookEvents[i].ID == event.ID {
+			r.store.webhookEvents[i] = *event
+
+			return nil
+		}
+	}
+
+	return repository.ErrNotFound
+}
+
+func (r *WebhookEventRepository) FindByID(_ context.Context, id uint) (*models.WebhookEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, event := range r.store.webhookEvents {
+		if event.ID == id {
+			return &event, nil
+		}
+	}
+
+	return nil, repository.ErrNotFound
+}
+
+func (r *WebhookEventRepository) List(_ context.Context, limit int) ([]models.WebhookEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	events := make([]models.WebhookEvent, len(r.store.webhookEvents))
+	copy(events, r.store.webhookEvents)
+	sort.Slice(events, func(i, j int) bool { return events[i].ID > events[j].ID })
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
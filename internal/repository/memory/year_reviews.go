@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type YearReviewRepository struct {
+	store *store
+}
+
+func (r *YearReviewRepository) Find(_ context.Context, userID string, year int) (*models.YearReview, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, review := range r.store.yearReviews {
+		if review.UserID == userID && review.Year == year {
+			return &review, nil
+		}
+	}
+
+	return nil, repository.ErrNotFound
+}
+
+func (r *YearReviewRepository) Save(_ context.Context, review *models.YearReview) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.yearReviews {
+		if existing.UserID == review.UserID && existing.Year == review.Year {
+			return nil
+		}
+	}
+
+	r.store.nextYearReviewID++
+	review.ID = r.store.nextYearReviewID
+	r.store.yearReviews = append(r.store.yearReviews, *review)
+
+	return nil
+}
+
+func (r *YearReviewRepository) Delete(_ context.Context, userID string, year int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	reviews := r.store.yearReviews[:0]
+	for _, review := range r.store.yearReviews {
+		if review.UserID != userID || review.Year != year {
+			reviews = append(reviews, review)
+		}
+	}
+	r.store.yearReviews = reviews
+
+	return nil
+}
+
+func (r *YearReviewRepository) DeleteForUser(_ context.Context, userID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	reviews := r.store.yearReviews[:0]
+	for _, review := range r.store.yearReviews {
+		if review.UserID != userID {
+			reviews = append(reviews, review)
+		}
+	}
+	r.store.yearReviews = reviews
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type AccessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAccessTokenRepository(db *gorm.DB) *AccessTokenRepository {
+	return &AccessTokenRepository{db: db}
+}
+
+func (r *AccessTokenRepository) Create(ctx context.Context, token *models.AccessToken) error {
+	return translateError(r.db.WithContext(ctx).Create(token).Error)
+}
+
+func (r *AccessTokenRepository) FindByHash(ctx context.Context, hash string) (*models.AccessToken, error) {
+	var token models.AccessToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return &token, nil
+}
+
+func (r *AccessTokenRepository) ListForUser(ctx context.Context, userID string) ([]models.AccessToken, error) {
+	var tokens []models.AccessToken
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Find(&tokens).Error
+
+	return tokens, translateError(err)
+}
+
+func (r *AccessTokenRepository) Delete(ctx context.Context, userID string, id uint) error {
+	result := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).Delete(&models.AccessToken{})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *AccessTokenRepository) MarkUsed(ctx context.Context, id uint, at time.Time) error {
+	return translateError(r.db.WithContext(ctx).Model(&models.AccessToken{}).Where("id = ?", id).Update("last_used_at", at).Error)
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+)
+
+type AchievementRepository struct {
+	db *gorm.DB
+}
+
+func NewAchievementRepository(db *gorm.DB) *AchievementRepository {
+	return &AchievementRepository{db: db}
+}
+
+func (r *AchievementRepository) ListForUser(ctx context.Context, userID string) ([]models.UserAchievement, error) {
+	var achievements []models.UserAchievement
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("awarded_at ASC").Find(&achievements).Error
+
+	return achievements, translateError(err)
+}
+
+func (r *AchievementRepository) Award(ctx context.Context, achievement *models.UserAchievement) (bool, error) {
+	// A concurrent request may have awarded the same badge, the unique index keeps it single
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(achievement)
+	if result.Error != nil {
+		return false, translateError(result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+)
+
+type AuditEventRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditEventRepository(db *gorm.DB) *AuditEventRepository {
+	return &AuditEventRepository{db: db}
+}
+
+func (r *AuditEventRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	return translateError(r.db.WithContext(ctx).Create(event).Error)
+}
+
+func (r *AuditEventRepository) ListForUser(ctx context.Context, userID string, beforeID uint, limit int) ([]models.AuditEvent, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+
+	var events []models.AuditEvent
+	err := query.Order("id DESC").Limit(limit).Find(&events).Error
+
+	return events, translateError(err)
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+// Compile-time checks that every repository satisfies its interface
+var (
+	_ repository.UserRepository         = (*UserRepository)(nil)
+	_ repository.SessionRepository      = (*SessionRepository)(nil)
+	_ repository.AchievementRepository  = (*AchievementRepository)(nil)
+	_ repository.YearReviewRepository   = (*YearReviewRepository)(nil)
+	_ repository.WebhookEventRepository = (*WebhookEventRepository)(nil)
+	_ repository.AccessTokenRepository  = (*AccessTokenRepository)(nil)
+	_ repository.AuditEventRepository   = (*AuditEventRepository)(nil)
+	_ repository.PreferencesRepository  = (*PreferencesRepository)(nil)
+	_ repository.ReminderRepository     = (*ReminderRepository)(nil)
+	_ repository.StatsRepository        = (*StatsRepository)(nil)
+)
+
+// NewRepositories returns GORM-backed implementations of every repository sharing one connection
+func NewRepositories(db *gorm.DB) *repository.Repositories {
+	return &repository.Repositories{
+		Users:         NewUserRepository(db),
+		Sessions:      NewSessionRepository(db),
+		Achievements:  NewAchievementRepository(db),
+		YearReviews:   NewYearReviewRepository(db),
+		WebhookEvents: NewWebhookEventRepository(db),
+		AccessTokens:  NewAccessTokenRepository(db),
+		AuditEvents:   NewAuditEventRepository(db),
+		Preferences:   NewPreferencesRepository(db),
+		Reminders:     NewReminderRepository(db),
+		Stats:         NewStatsRepository(db),
+	}
+}
+
+// translateError maps GORM errors onto the repository package's sentinel errors
+func translateError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return repository.ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return repository.ErrDuplicate
+	default:
+		return err
+	}
+}
+
+// withinRange restricts a query on created_at to [from, to), ignoring zero bounds. created_at
+// holds UTC without a zone, so bounds built in a user's timezone are converted to UTC first.
+func withinRange(query *gorm.DB, from, to time.Time) *gorm.DB {
+	if !from.IsZero() {
+		query = query.Where("created_at >= ?", from.UTC())
+	}
+
+	if !to.IsZero() {
+		query = query.Where("created_at < ?", to.UTC())
+	}
+
+	return query
+}
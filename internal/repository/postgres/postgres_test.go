@@ -0,0 +1,535 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/postgres"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRepository(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer testutils.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	repos := postgres.NewRepositories(db)
+
+	// Helper function to clean database before each test
+	cleanDB := func() {
+		testutils.TruncateTable(db, "users")
+	}
+
+	t.Run("return user when found by clerk user id", func(t *testing.T) {
+		cleanDB()
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+
+		found, err := repos.Users.FindByClerkUserID(ctx, "user_123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+	})
+
+	t.Run("return duplicate error when clerk user id already exists", func(t *testing.T) {
+		cleanDB()
+
+		assert.NoError(t, repos.Users.Create(ctx, testutils.CreateTestUser("user_123")))
+
+		err := repos.Users.Create(ctx, testutils.CreateTestUser("user_123"))
+
+		assert.ErrorIs(t, err, repository.ErrDuplicate)
+	})
+
+	t.Run("return not found error when user has been deleted", func(t *testing.T) {
+		cleanDB()
+
+		assert.NoError(t, repos.Users.Create(ctx, testutils.CreateTestUser("user_123")))
+		assert.NoError(t, repos.Users.DeleteByClerkUserID(ctx, "user_123"))
+
+		_, err := repos.Users.FindByClerkUserID(ctx, "user_123")
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("set only the deletion request date", func(t *testing.T) {
+		cleanDB()
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		stale := *user
+		user.Email = "new@example.com"
+		assert.NoError(t, repos.Users.Update(ctx, user))
+
+		requestedAt := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+		assert.NoError(t, repos.Users.SetDeletionRequestedAt(ctx, stale.ID, &requestedAt))
+
+		found, err := repos.Users.FindByID(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", found.Email)
+		if assert.NotNil(t, found.DeletionRequestedAt) {
+			assert.True(t, requestedAt.Equal(*found.DeletionRequestedAt))
+		}
+
+		assert.NoError(t, repos.Users.SetDeletionRequestedAt(ctx, user.ID, nil))
+		found, err = repos.Users.FindByID(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, found.DeletionRequestedAt)
+		assert.ErrorIs(t, repos.Users.SetDeletionRequestedAt(ctx, "missing", nil), repository.ErrNotFound)
+	})
+
+	t.Run("successfully restore deleted user", func(t *testing.T) {
+		cleanDB()
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		assert.Equal(t, models.RoleUser, user.Role)
+		assert.NoError(t, repos.Users.DeleteByClerkUserID(ctx, "user_123"))
+
+		restored, err := repos.Users.Restore(ctx, user.ID)
+
+		assert.NoError(t, err)
+		assert.False(t, restored.DeletedAt.Valid)
+		_, err = repos.Users.FindByClerkUserID(ctx, "user_123")
+		assert.NoError(t, err)
+
+		_, err = repos.Users.Restore(ctx, user.ID)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("list users due for purge including soft-deleted ones", func(t *testing.T) {
+		cleanDB()
+
+		requested := testutils.CreateTestUser("user_requested")
+		requestedAt := time.Now().UTC().Add(-time.Hour)
+		requested.DeletionRequestedAt = &requestedAt
+		assert.NoError(t, repos.Users.Create(ctx, requested))
+		deleted := testutils.CreateTestUser("user_deleted")
+		assert.NoError(t, repos.Users.Create(ctx, deleted))
+		assert.NoError(t, repos.Users.DeleteByClerkUserID(ctx, "user_deleted"))
+		assert.NoError(t, repos.Users.Create(ctx, testutils.CreateTestUser("user_active")))
+
+		// A cutoff in a zone far from UTC selects the same users
+		kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+		assert.NoError(t, err)
+		now := time.Now().In(kiritimati)
+
+		due, err := repos.Users.ListDueForPurge(ctx, now.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Len(t, due, 2)
+
+		due, err = repos.Users.ListDueForPurge(ctx, now.Add(-2*time.Hour))
+		assert.NoError(t, err)
+		assert.Empty(t, due)
+	})
+
+	t.Run("permanently remove user and their sessions when hard deleted", func(t *testing.T) {
+		cleanDB()
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		assert.NoError(t, repos.Sessions.Create(ctx, testutils.CreateTestSession(user.ID)))
+
+		assert.NoError(t, repos.Users.HardDelete(ctx, user.ID))
+
+		users, err := repos.Users.Search(ctx, repository.UserSearch{ID: user.ID})
+		assert.NoError(t, err)
+		assert.Empty(t, users)
+
+		sessions, err := repos.Sessions.ListWithDeleted(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+}
+
+func TestSessionRepository(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer testutils.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	repos := postgres.NewRepositories(db)
+
+	// Helper function to clean database and create a user owning the sessions
+	setupUser := func(t *testing.T) *models.User {
+		testutils.TruncateTable(db, "sessions")
+		testutils.TruncateTable(db, "users")
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+
+		return user
+	}
+
+	createSession := func(t *testing.T, userID string, seconds int, sessionType string, createdAt time.Time) *models.Session {
+		session := testutils.CreateTestSession(userID)
+		session.DurationSeconds = seconds
+		session.SessionType = sessionType
+		session.CreatedAt = createdAt
+		assert.NoError(t, repos.Sessions.Create(ctx, session))
+
+		return session
+	}
+
+	t.Run("store generated timestamps as UTC on a host in another timezone", func(t *testing.T) {
+		user := setupUser(t)
+		kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+		assert.NoError(t, err)
+		local := time.Local
+		time.Local = kiritimati
+		defer func() { time.Local = local }()
+
+		// Left zero, GORM fills in the creation time
+		session := &models.Session{UserID: user.ID, DurationSeconds: 600, SessionType: "mindfulness"}
+		assert.NoError(t, repos.Sessions.Create(ctx, session))
+
+		var skew float64
+		err = db.Raw("SELECT ABS(EXTRACT(EPOCH FROM created_at - (NOW() AT TIME ZONE 'UTC'))) FROM sessions WHERE id = ?", session.ID).Scan(&skew).Error
+		assert.NoError(t, err)
+		assert.Less(t, skew, 60.0)
+	})
+
+	t.Run("return daily totals in ascending date order", func(t *testing.T) {
+		user := setupUser(t)
+
+		createSession(t, user.ID, 600, "mindfulness", time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC))
+		createSession(t, user.ID, 300, "breathing", time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))
+		createSession(t, user.ID, 900, "mindfulness", time.Date(2025, 3, 2, 18, 0, 0, 0, time.UTC))
+
+		totals, err := repos.Sessions.DailyTotals(ctx, user.ID, time.Time{}, time.Time{}, time.UTC)
+
+		assert.NoError(t, err)
+		assert.Len(t, totals, 2)
+		assert.Equal(t, "2025-03-01", totals[0].Day.Format("2006-01-02"))
+		assert.Equal(t, 300, totals[0].Seconds)
+		assert.Equal(t, 1, totals[0].Sessions)
+		assert.Equal(t, "2025-03-02", totals[1].Day.Format("2006-01-02"))
+		assert.Equal(t, 1500, totals[1].Seconds)
+		assert.Equal(t, 2, totals[1].Sessions)
+	})
+
+	t.Run("group daily totals by calendar day in the given timezone", func(t *testing.T) {
+		user := setupUser(t)
+
+		createSession(t, user.ID, 600, "mindfulness", time.Date(2025, 3, 1, 23, 30, 0, 0, time.UTC))
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		assert.NoError(t, err)
+
+		totals, err := repos.Sessions.DailyTotals(ctx, user.ID, time.Time{}, time.Time{}, tokyo)
+
+		assert.NoError(t, err)
+		assert.Len(t, totals, 1)
+		assert.Equal(t, "2025-03-02", totals[0].Day.Format("2006-01-02"))
+	})
+
+	t.Run("bound daily totals by instants in the given timezone", func(t *testing.T) {
+		user := setupUser(t)
+		losAngeles, err := time.LoadLocation("America/Los_Angeles")
+		assert.NoError(t, err)
+
+		// New Year's Eve evening in Los Angeles is already the next year in UTC
+		createSession(t, user.ID, 600, "mindfulness", time.Date(2024, 12, 31, 20, 0, 0, 0, losAngeles).UTC())
+		createSession(t, user.ID, 300, "breathing", time.Date(2025, 1, 1, 20, 0, 0, 0, losAngeles).UTC())
+
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, losAngeles)
+		totals, err := repos.Sessions.DailyTotals(ctx, user.ID, from, from.AddDate(1, 0, 0), losAngeles)
+
+		assert.NoError(t, err)
+		assert.Len(t, totals, 1)
+		assert.Equal(t, "2025-01-01", totals[0].Day.Format("2006-01-02"))
+		assert.Equal(t, 300, totals[0].Seconds)
+	})
+
+	t.Run("count sessions started before an hour in the given timezone", func(t *testing.T) {
+		user := setupUser(t)
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		assert.NoError(t, err)
+
+		// 04:30 UTC is 05:30 in Berlin, 05:30 UTC is 06:30
+		createSession(t, user.ID, 600, "mindfulness", time.Date(2025, 3, 1, 4, 30, 0, 0, time.UTC))
+		createSession(t, user.ID, 600, "mindfulness", time.Date(2025, 3, 2, 5, 30, 0, 0, time.UTC))
+
+		count, err := repos.Sessions.CountStartedBeforeHour(ctx, user.ID, 6, berlin)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("return most practised session type first", func(t *testing.T) {
+		user := setupUser(t)
+
+		createSession(t, user.ID, 600, "mindfulness", time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))
+		createSession(t, user.ID, 300, "breathing", time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC))
+		createSession(t, user.ID, 300, "breathing", time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC))
+
+		totals, err := repos.Sessions.TypeTotals(ctx, user.ID, time.Time{}, time.Time{})
+
+		assert.NoError(t, err)
+		assert.Len(t, totals, 2)
+		assert.Equal(t, "breathing", totals[0].SessionType)
+		assert.Equal(t, 2, totals[0].Sessions)
+	})
+
+	t.Run("aggregate tags and mood ratings", func(t *testing.T) {
+		user := setupUser(t)
+		mood := func(value int) *int {
+			return &value
+		}
+
+		sessions := []models.Session{
+			{UserID: user.ID, DurationSeconds: 600, SessionType: "breathing", Tags: []string{"sleep", "evening"}, MoodBefore: mood(2), MoodAfter: mood(4)},
+			{UserID: user.ID, DurationSeconds: 300, SessionType: "breathing", Tags: []string{"sleep"}, MoodBefore: mood(3)},
+			{UserID: user.ID, DurationSeconds: 300, SessionType: "walking"},
+		}
+		for i := range sessions {
+			assert.NoError(t, repos.Sessions.Create(ctx, &sessions[i]))
+		}
+
+		tags, err := repos.Sessions.TagTotals(ctx, user.ID, time.Time{}, time.Time{})
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.TagTotal{
+			{Tag: "sleep", Seconds: 900, Sessions: 2},
+			{Tag: "evening", Seconds: 600, Sessions: 1},
+		}, tags)
+
+		moods, err := repos.Sessions.MoodTotals(ctx, user.ID, time.Time{}, time.Time{})
+		assert.NoError(t, err)
+		assert.Equal(t, repository.MoodTotals{Sessions: 1, MoodBefore: 2, MoodAfter: 4}, moods)
+	})
+
+	t.Run("return not found error when there is no longest session", func(t *testing.T) {
+		user := setupUser(t)
+
+		_, err := repos.Sessions.Longest(ctx, user.ID, time.Time{}, time.Time{})
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("exclude deleted sessions from reads", func(t *testing.T) {
+		user := setupUser(t)
+
+		session := createSession(t, user.ID, 600, "mindfulness", time.Now())
+		assert.NoError(t, repos.Sessions.Delete(ctx, session))
+
+		_, err := repos.Sessions.FindForUser(ctx, user.ID, session.ID)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		sessions, err := repos.Sessions.ListBefore(ctx, user.ID, 0, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+
+	t.Run("successfully restore deleted session", func(t *testing.T) {
+		user := setupUser(t)
+
+		session := createSession(t, user.ID, 600, "mindfulness", time.Now())
+		assert.NoError(t, repos.Sessions.Delete(ctx, session))
+
+		restored, err := repos.Sessions.Restore(ctx, user.ID, session.ID)
+		assert.NoError(t, err)
+		assert.False(t, restored.DeletedAt.Valid)
+
+		_, err = repos.Sessions.FindForUser(ctx, user.ID, session.ID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAccessTokenRepository(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer testutils.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	repos := postgres.NewRepositories(db)
+
+	t.Run("find token by hash with its scopes", func(t *testing.T) {
+		testutils.TruncateTable(db, "users")
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		token := &models.AccessToken{UserID: user.ID, Name: "Shortcut", Prefix: "mmpat_abcdef", TokenHash: strings.Repeat("a", 64), Scopes: []string{"sessions:write"}}
+		assert.NoError(t, repos.AccessTokens.Create(ctx, token))
+
+		found, err := repos.AccessTokens.FindByHash(ctx, token.TokenHash)
+
+		assert.NoError(t, err)
+		assert.Equal(t, token.ID, found.ID)
+		assert.Equal(t, []string{"sessions:write"}, found.Scopes)
+	})
+
+	t.Run("return not found when deleting another user's token", func(t *testing.T) {
+		testutils.TruncateTable(db, "users")
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		token := &models.AccessToken{UserID: user.ID, Name: "Shortcut", Prefix: "mmpat_abcdef", TokenHash: strings.Repeat("b", 64), Scopes: []string{"stats:read"}}
+		assert.NoError(t, repos.AccessTokens.Create(ctx, token))
+
+		err := repos.AccessTokens.Delete(ctx, "01HZZZZZZZZZZZZZZZZZZZZZZZ", token.ID)
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("record when token was last used", func(t *testing.T) {
+		testutils.TruncateTable(db, "users")
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		token := &models.AccessToken{UserID: user.ID, Name: "Shortcut", Prefix: "mmpat_abcdef", TokenHash: strings.Repeat("c", 64), Scopes: []string{"stats:read"}}
+		assert.NoError(t, repos.AccessTokens.Create(ctx, token))
+
+		usedAt := time.Now().UTC().Truncate(time.Second)
+		assert.NoError(t, repos.AccessTokens.MarkUsed(ctx, token.ID, usedAt))
+
+		tokens, err := repos.AccessTokens.ListForUser(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Len(t, tokens, 1)
+		assert.WithinDuration(t, usedAt, *tokens[0].LastUsedAt, time.Second)
+	})
+}
+
+func TestAuditEventRepository(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer testutils.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	repos := postgres.NewRepositories(db)
+
+	t.Run("list events newest first with their changes", func(t *testing.T) {
+		testutils.TruncateTable(db, "users")
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		for _, action := range []string{"session.created", "session.deleted"} {
+			assert.NoError(t, repos.AuditEvents.Create(ctx, &models.AuditEvent{
+				UserID: user.ID, ActorType: "user", ActorID: user.ID, Action: action, TargetType: "session", TargetID: "1",
+				Changes: map[string]models.AuditChange{"notes": {Before: nil, After: "calm"}},
+			}))
+		}
+
+		events, err := repos.AuditEvents.ListForUser(ctx, user.ID, 0, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, "session.deleted", events[0].Action)
+		assert.Equal(t, "calm", events[0].Changes["notes"].After)
+
+		older, err := repos.AuditEvents.ListForUser(ctx, user.ID, events[0].ID, 10)
+		assert.NoError(t, err)
+		assert.Len(t, older, 1)
+		assert.Equal(t, "session.created", older[0].Action)
+	})
+
+	t.Run("reject updates to recorded events", func(t *testing.T) {
+		testutils.TruncateTable(db, "users")
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		event := &models.AuditEvent{UserID: user.ID, ActorType: "system", Action: "user.created", TargetType: "user", TargetID: user.ID, Changes: map[string]models.AuditChange{}}
+		assert.NoError(t, repos.AuditEvents.Create(ctx, event))
+
+		err := db.Model(event).Update("action", "user.deleted").Error
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPreferencesRepository(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer testutils.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	repos := postgres.NewRepositories(db)
+
+	t.Run("return defaults until preferences are saved", func(t *testing.T) {
+		testutils.TruncateTable(db, "users")
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+
+		preferences, err := repos.Preferences.Get(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.DefaultPreferences(user.ID), preferences)
+
+		preferences.Timezone = "Europe/Berlin"
+		preferences.RemindersEnabled = true
+		assert.NoError(t, repos.Preferences.Save(ctx, preferences))
+		preferences.WeekStart = models.WeekStartSunday
+		assert.NoError(t, repos.Preferences.Save(ctx, preferences))
+
+		stored, err := repos.Preferences.Get(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Europe/Berlin", stored.Timezone)
+		assert.Equal(t, models.WeekStartSunday, stored.WeekStart)
+		assert.True(t, stored.RemindersEnabled)
+	})
+}
+
+func TestReminderRepository(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer testutils.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	repos := postgres.NewRepositories(db)
+
+	t.Run("claim each occurrence once", func(t *testing.T) {
+		testutils.TruncateTable(db, "users")
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		reminder := &models.Reminder{UserID: user.ID, Days: []string{"monday", "friday"}, LocalTime: "07:30", Enabled: true}
+		assert.NoError(t, repos.Reminders.Create(ctx, reminder))
+
+		enabled, err := repos.Reminders.ListEnabled(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, enabled, 1)
+		assert.Equal(t, []string{"monday", "friday"}, enabled[0].Days)
+
+		dueAt := time.Now().Truncate(time.Minute)
+		claimed, err := repos.Reminders.MarkTriggered(ctx, reminder.ID, dueAt, dueAt.Add(time.Second))
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+		claimed, err = repos.Reminders.MarkTriggered(ctx, reminder.ID, dueAt, dueAt.Add(2*time.Second))
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+		claimed, err = repos.Reminders.MarkTriggered(ctx, reminder.ID, dueAt.AddDate(0, 0, 1), dueAt.AddDate(0, 0, 1))
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+	})
+
+	t.Run("claim an occurrence due in a zone ahead of UTC only once", func(t *testing.T) {
+		testutils.TruncateTable(db, "users")
+		sydney, err := time.LoadLocation("Australia/Sydney")
+		assert.NoError(t, err)
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		reminder := &models.Reminder{UserID: user.ID, Days: []string{"monday"}, LocalTime: "07:30", Enabled: true}
+		assert.NoError(t, repos.Reminders.Create(ctx, reminder))
+
+		dueAt := time.Date(2025, 3, 10, 7, 30, 0, 0, sydney)
+		claimed, err := repos.Reminders.MarkTriggered(ctx, reminder.ID, dueAt, dueAt.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+		claimed, err = repos.Reminders.MarkTriggered(ctx, reminder.ID, dueAt, dueAt.Add(2*time.Minute))
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+	})
+
+	t.Run("return no reminders of deleted users", func(t *testing.T) {
+		testutils.TruncateTable(db, "users")
+
+		user := testutils.CreateTestUser("user_123")
+		assert.NoError(t, repos.Users.Create(ctx, user))
+		assert.NoError(t, repos.Reminders.Create(ctx, &models.Reminder{UserID: user.ID, Days: []string{"monday"}, LocalTime: "07:30", Enabled: true}))
+		assert.NoError(t, repos.Users.DeleteByClerkUserID(ctx, user.ClerkUserID))
+
+		enabled, err := repos.Reminders.ListEnabled(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, enabled)
+	})
+}
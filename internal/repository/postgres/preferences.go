@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type PreferencesRepository struct {
+	db *gorm.DB
+}
+
+func NewPreferencesRepository(db *gorm.DB) *PreferencesRepository {
+	return &PreferencesRepository{db: db}
+}
+
+func (r *PreferencesRepository) Get(ctx context.Context, userID string) (*models.UserPreferences, error) {
+	var preferences models.UserPreferences
+	err := translateError(r.db.WithContext(ctx).Where("user_id = ?", userID).First(&preferences).Error)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.DefaultPreferences(userID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &preferences, nil
+}
+
+// Save updates the user's row, inserting it the first time their preferences are changed
+func (r *PreferencesRepository) Save(ctx context.Context, preferences *models.UserPreferences) error {
+	return translateError(r.db.WithContext(ctx).Save(preferences).Error)
+}
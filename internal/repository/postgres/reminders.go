@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type ReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+func (r *ReminderRepository) Create(ctx context.Context, reminder *models.Reminder) error {
+	return translateError(r.db.WithContext(ctx).Create(reminder).Error)
+}
+
+func (r *ReminderRepository) Update(ctx context.Context, reminder *models.Reminder) error {
+	return translateError(r.db.WithContext(ctx).Save(reminder).Error)
+}
+
+func (r *ReminderRepository) FindForUser(ctx context.Context, userID string, id uint) (*models.Reminder, error) {
+	var reminder models.Reminder
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&reminder).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return &reminder, nil
+}
+
+func (r *ReminderRepository) ListForUser(ctx context.Context, userID string) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("local_time ASC, id ASC").Find(&reminders).Error
+
+	return reminders, translateError(err)
+}
+
+func (r *ReminderRepository) Delete(ctx context.Context, userID string, id uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.Reminder{})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *ReminderRepository) ListEnabled(ctx context.Context) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	err := r.db.WithContext(ctx).
+		Joins("JOIN users ON users.id = reminders.user_id AND users.deleted_at IS NULL").
+		Where("reminders.enabled").
+		Order("reminders.local_time ASC, reminders.id ASC").
+		Find(&reminders).Error
+
+	return reminders, translateError(err)
+}
+
+func (r *ReminderRepository) MarkTriggered(ctx context.Context, id uint, dueAt, at time.Time) (bool, error) {
+	// The condition makes the claim atomic, only one replica updates the row for an occurrence.
+	// The column has no zone, so both times are compared and stored as UTC.
+	result := r.db.WithContext(ctx).Model(&models.Reminder{}).
+		Where("id = ? AND (last_triggered_at IS NULL OR last_triggered_at < ?)", id, dueAt.UTC()).
+		UpdateColumn("last_triggered_at", at.UTC())
+	if result.Error != nil {
+		return false, translateError(result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
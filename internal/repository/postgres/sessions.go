@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) error {
+	return translateError(r.db.WithContext(ctx).Create(session).Error)
+}
+
+func (r *SessionRepository) FindForUser(ctx context.Context, userID string, id uint) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&session).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return &session, nil
+}
+
+// Delete soft deletes the session
+func (r *SessionRepository) Delete(ctx context.Context, session *models.Session) error {
+	return translateError(r.db.WithContext(ctx).Delete(session).Error)
+}
+
+func (r *SessionRepository) ListBefore(ctx context.Context, userID string, beforeID uint, limit int) ([]models.Session, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+
+	var sessions []models.Session
+	err := query.Order("id DESC").Limit(limit).Find(&sessions).Error
+
+	return sessions, translateError(err)
+}
+
+func (r *SessionRepository) Recent(ctx context.Context, userID string, limit int) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&sessions).Error
+
+	return sessions, translateError(err)
+}
+
+func (r *SessionRepository) Longest(ctx context.Context, userID string, from, to time.Time) (*models.Session, error) {
+	query := withinRange(r.db.WithContext(ctx).Where("user_id = ?", userID), from, to)
+
+	var session models.Session
+	if err := query.Order("duration_seconds DESC, created_at ASC").First(&session).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return &session, nil
+}
+
+func (r *SessionRepository) DailyTotals(ctx context.Context, userID string, from, to time.Time, loc *time.Location) ([]repository.DailyTotal, error) {
+	query := withinRange(r.db.WithContext(ctx).Model(&models.Session{}).Where("user_id = ?", userID), from, to)
+
+	// created_at holds UTC wall time, convert it to loc before taking the date
+	var totals []repository.DailyTotal
+	err := query.
+		Select("DATE(created_at AT TIME ZONE 'UTC' AT TIME ZONE ?) AS day, COALESCE(SUM(duration_seconds), 0) AS seconds, COUNT(*) AS sessions", loc.String()).
+		Group("day").
+		Order("day ASC").
+		Scan(&totals).Error
+
+	return totals, translateError(err)
+}
+
+func (r *SessionRepository) TypeTotals(ctx context.Context, userID string, from, to time.Time) ([]repository.TypeTotal, error) {
+	query := withinRange(r.db.WithContext(ctx).Model(&models.Session{}).Where("user_id = ?", userID), from, to)
+
+	var totals []repository.TypeTotal
+	err := query.
+		Select("session_type, COALESCE(SUM(duration_seconds), 0) AS seconds, COUNT(*) AS sessions").
+		Group("session_type").
+		Order("sessions DESC, seconds DESC, session_type ASC").
+		Scan(&totals).Error
+
+	return totals, translateError(err)
+}
+
+func (r *SessionRepository) TagTotals(ctx context.Context, userID string, from, to time.Time) ([]repository.TagTotal, error) {
+	query := withinRange(r.db.WithContext(ctx).Model(&models.Session{}).Where("user_id = ?", userID), from, to)
+
+	var totals []repository.TagTotal
+	err := query.
+		Joins("CROSS JOIN LATERAL jsonb_array_elements_text(sessions.tags) AS session_tags(tag)").
+		Select("session_tags.tag, COALESCE(SUM(duration_seconds), 0) AS seconds, COUNT(*) AS sessions").
+		Group("session_tags.tag").
+		Order("sessions DESC, seconds DESC, session_tags.tag ASC").
+		Scan(&totals).Error
+
+	return totals, translateError(err)
+}
+
+func (r *SessionRepository) MoodTotals(ctx context.Context, userID string, from, to time.Time) (repository.MoodTotals, error) {
+	query := withinRange(r.db.WithContext(ctx).Model(&models.Session{}).Where("user_id = ?", userID), from, to)
+
+	var totals repository.MoodTotals
+	err := query.
+		Where("mood_before IS NOT NULL AND mood_after IS NOT NULL").
+		Select("COUNT(*) AS sessions, COALESCE(SUM(mood_before), 0) AS mood_before, COALESCE(SUM(mood_after), 0) AS mood_after").
+		Scan(&totals).Error
+
+	return totals, translateError(err)
+}
+
+func (r *SessionRepository) CountStartedBeforeHour(ctx context.Context, userID string, hour int, loc *time.Location) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("user_id = ? AND EXTRACT(HOUR FROM created_at AT TIME ZONE 'UTC' AT TIME ZONE ?) < ?", userID, loc.String(), hour).
+		Count(&count).Error
+
+	return int(count), translateError(err)
+}
+
+func (r *SessionRepository) ListWithDeleted(ctx context.Context, userID string) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.WithContext(ctx).Unscoped().Where("user_id = ?", userID).Order("id DESC").Find(&sessions).Error
+
+	return sessions, translateError(err)
+}
+
+func (r *SessionRepository) Restore(ctx context.Context, userID string, id uint) (*models.Session, error) {
+	var session models.Session
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).
+		First(&session).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	if err := r.db.WithContext(ctx).Unscoped().Model(&session).Update("deleted_at", nil).Error; err != nil {
+		return nil, translateError(err)
+	}
+	session.DeletedAt = gorm.DeletedAt{}
+
+	return &session, nil
+}
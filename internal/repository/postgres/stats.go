@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type StatsRepository struct {
+	db *gorm.DB
+}
+
+func NewStatsRepository(db *gorm.DB) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+func (r *StatsRepository) Totals(ctx context.Context) (repository.Totals, error) {
+	var totals repository.Totals
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			(SELECT COUNT(*) FROM users WHERE deleted_at IS NULL) AS users,
+			(SELECT COUNT(*) FROM users WHERE deleted_at IS NOT NULL) AS deleted_users,
+			(SELECT COUNT(*) FROM sessions WHERE deleted_at IS NULL) AS sessions,
+			(SELECT COUNT(*) FROM sessions WHERE deleted_at IS NOT NULL) AS deleted_sessions,
+			(SELECT COALESCE(SUM(duration_seconds), 0) FROM sessions WHERE deleted_at IS NULL) AS total_seconds,
+			(SELECT COUNT(*) FROM user_achievements) AS achievements,
+			(SELECT COUNT(*) FROM webhook_events) AS webhook_events
+	`).Scan(&totals).Error
+
+	return totals, translateError(err)
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	return translateError(r.db.WithContext(ctx).Create(user).Error)
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	return translateError(r.db.WithContext(ctx).Save(user).Error)
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) FindByClerkUserID(ctx context.Context, clerkUserID string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("clerk_user_id = ?", clerkUserID).First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return &user, nil
+}
+
+// DeleteByClerkUserID soft deletes the user
+func (r *UserRepository) DeleteByClerkUserID(ctx context.Context, clerkUserID string) error {
+	return translateError(r.db.WithContext(ctx).Where("clerk_user_id = ?", clerkUserID).Delete(&models.User{}).Error)
+}
+
+func (r *UserRepository) Restore(ctx context.Context, id string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&user).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	err = r.db.WithContext(ctx).Unscoped().Model(&user).
+		Updates(map[string]any{"deleted_at": nil, "deletion_requested_at": nil}).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	user.DeletedAt = gorm.DeletedAt{}
+	user.DeletionRequestedAt = nil
+
+	return &user, nil
+}
+
+func (r *UserRepository) SetDeletionRequestedAt(ctx context.Context, id string, at *time.Time) error {
+	var value any
+	if at != nil {
+		value = at.UTC()
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("deletion_requested_at", value)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) Search(ctx context.Context, search repository.UserSearch) ([]models.User, error) {
+	query := r.db.WithContext(ctx).Unscoped()
+
+	if search.ID != "" {
+		query = query.Where("id = ?", search.ID)
+	}
+
+	if search.Email != "" {
+		query = query.Where("LOWER(email) = LOWER(?)", search.Email)
+	}
+
+	if search.ClerkUserID != "" {
+		query = query.Where("clerk_user_id = ?", search.ClerkUserID)
+	}
+
+	var users []models.User
+	err := query.Order("created_at ASC").Find(&users).Error
+
+	return users, translateError(err)
+}
+
+func (r *UserRepository) ListDueForPurge(ctx context.Context, cutoff time.Time) ([]models.User, error) {
+	// Both columns hold UTC without a zone
+	var users []models.User
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deletion_requested_at < ? OR deleted_at < ?", cutoff.UTC(), cutoff.UTC()).
+		Order("created_at").
+		Find(&users).Error
+
+	return users, translateError(err)
+}
+
+// HardDelete removes the user row, relying on ON DELETE CASCADE for sessions, achievements, reviews, access tokens, audit events, preferences and reminders
+func (r *UserRepository) HardDelete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Unscoped().Where("id = ?", id).First(&user).Error; err != nil {
+			return translateError(err)
+		}
+
+		if err := tx.Where("clerk_user_id = ?", user.ClerkUserID).Delete(&models.WebhookEvent{}).Error; err != nil {
+			return translateError(err)
+		}
+
+		return translateError(tx.Unscoped().Delete(&user).Error)
+	})
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+)
+
+type WebhookEventRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookEventRepository(db *gorm.DB) *WebhookEventRepository {
+	return &WebhookEventRepository{db: db}
+}
+
+func (r *WebhookEventRepository) Create(ctx context.Context, event *models.WebhookEvent) error {
+	return translateError(r.db.WithContext(ctx).Create(event).Error)
+}
+
+func (r *WebhookEventRepository) Update(ctx context.Context, event *models.WebhookEvent) error {
+	return translateError(r.db.WithContext(ctx).Save(event).Error)
+}
+
+func (r *WebhookEventRepository) FindByID(ctx context.Context, id uint) (*models.WebhookEvent, error) {
+	var event models.WebhookEvent
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&event).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return &event, nil
+}
+
+func (r *WebhookEventRepository) List(ctx context.Context, limit int) ([]models.WebhookEvent, error) {
+	var events []models.WebhookEvent
+	err := r.db.WithContext(ctx).Order("id DESC").Limit(limit).Find(&events).Error
+
+	return events, translateError(err)
+}
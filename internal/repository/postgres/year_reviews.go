@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+)
+
+type YearReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewYearReviewRepository(db *gorm.DB) *YearReviewRepository {
+	return &YearReviewRepository{db: db}
+}
+
+func (r *YearReviewRepository) Find(ctx context.Context, userID string, year int) (*models.YearReview, error) {
+	var review models.YearReview
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND year = ?", userID, year).First(&review).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return &review, nil
+}
+
+func (r *YearReviewRepository) Save(ctx context.Context, review *models.YearReview) error {
+	// A concurrent request may have cached the same year already, either copy is equivalent
+	return translateError(r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(review).Error)
+}
+
+func (r *YearReviewRepository) Delete(ctx context.Context, userID string, year int) error {
+	return translateError(r.db.WithContext(ctx).Where("user_id = ? AND year = ?", userID, year).Delete(&models.YearReview{}).Error)
+}
+
+func (r *YearReviewRepository) DeleteForUser(ctx context.Context, userID string) error {
+	return translateError(r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.YearReview{}).Error)
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+)
+
+// ErrNotFound is returned when a requested record does not exist
+var ErrNotFound = errors.New("record not found")
+
+// ErrDuplicate is returned when a record violates a uniqueness constraint
+var ErrDuplicate = errors.New("duplicate record")
+
+// DailyTotal holds the aggregated meditation time and session count for a single calendar day
+type DailyTotal struct {
+	Day      time.Time
+	Seconds  int
+	Sessions int
+}
+
+// TypeTotal holds the aggregated meditation time and session count for a single session type
+type TypeTotal struct {
+	SessionType string
+	Seconds     int
+	Sessions    int
+}
+
+// UserSearch selects users by any combination of identifiers; empty fields are ignored
+type UserSearch struct {
+	ID          string
+	Email       string
+	ClerkUserID string
+}
+
+// TagTotal holds the aggregated meditation time and session count for sessions carrying a tag
+type TagTotal struct {
+	Tag      string
+	Seconds  int
+	Sessions int
+}
+
+// MoodTotals sums the mood ratings of sessions rated both before and after
+type MoodTotals struct {
+	Sessions   int
+	MoodBefore int
+	MoodAfter  int
+}
+
+// Totals holds service-wide counts for operators
+type Totals struct {
+	Users           int
+	DeletedUsers    int
+	Sessions        int
+	DeletedSessions int
+	TotalSeconds    int
+	Achievements    int
+	WebhookEvents   int
+}
+
+// UserRepository persists users
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	FindByID(ctx context.Context, id string) (*models.User, error)
+	FindByClerkUserID(ctx context.Context, clerkUserID string) (*models.User, error)
+	DeleteByClerkUserID(ctx context.Context, clerkUserID string) error
+	// Restore undoes a soft delete and cancels any deletion request, returning ErrNotFound if
+	// there is no deleted user with that ID
+	Restore(ctx context.Context, id string) (*models.User, error)
+	// SetDeletionRequestedAt changes only when the user asked to delete their account, leaving
+	// the rest of the row as it is; nil cancels the request
+	SetDeletionRequestedAt(ctx context.Context, id string, at *time.Time) error
+	// Search returns users matching every non-empty field, including soft-deleted users
+	Search(ctx context.Context, search UserSearch) ([]models.User, error)
+	// ListDueForPurge returns users, including soft-deleted ones, whose deletion was requested or
+	// who were soft deleted before cutoff
+	ListDueForPurge(ctx context.Context, cutoff time.Time) ([]models.User, error)
+	// HardDelete permanently removes the user with their sessions, achievements, cached reviews, access tokens, audit events, preferences, reminders and webhook events
+	HardDelete(ctx context.Context, id string) error
+}
+
+// SessionRepository persists meditation sessions and answers the aggregate queries analytics are built on.
+// Soft-deleted sessions are excluded from every read. A zero from or to leaves that side of a range unbounded.
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	FindForUser(ctx context.Context, userID string, id uint) (*models.Session, error)
+	Delete(ctx context.Context, session *models.Session) error
+	// ListBefore returns up to limit sessions with an ID below beforeID (all when zero), newest ID first
+	ListBefore(ctx context.Context, userID string, beforeID uint, limit int) ([]models.Session, error)
+	// Recent returns up to limit sessions ordered by creation time, newest first
+	Recent(ctx context.Context, userID string, limit int) ([]models.Session, error)
+	// Longest returns the longest session in the range, or ErrNotFound if there is none
+	Longest(ctx context.Context, userID string, from, to time.Time) (*models.Session, error)
+	// DailyTotals returns per-day totals in ascending date order. Sessions are grouped by their
+	// calendar day in loc; each Day is midnight UTC on that date.
+	DailyTotals(ctx context.Context, userID string, from, to time.Time, loc *time.Location) ([]DailyTotal, error)
+	// TypeTotals returns per-session-type totals, most practised first
+	TypeTotals(ctx context.Context, userID string, from, to time.Time) ([]TypeTotal, error)
+	// TagTotals returns per-tag totals, most used first. A session counts towards each of its tags.
+	TagTotals(ctx context.Context, userID string, from, to time.Time) ([]TagTotal, error)
+	// MoodTotals sums the ratings of sessions with a mood both before and after
+	MoodTotals(ctx context.Context, userID string, from, to time.Time) (MoodTotals, error)
+	// CountStartedBeforeHour counts sessions created before the given hour of the day in loc
+	CountStartedBeforeHour(ctx context.Context, userID string, hour int, loc *time.Location) (int, error)
+	// ListWithDeleted returns every session for the user including soft-deleted ones, newest ID first
+	ListWithDeleted(ctx context.Context, userID string) ([]models.Session, error)
+	// Restore undoes a soft delete, returning ErrNotFound if the user has no deleted session with that ID
+	Restore(ctx context.Context, userID string, id uint) (*models.Session, error)
+}
+
+// AchievementRepository persists awarded badges
+type AchievementRepository interface {
+	ListForUser(ctx context.Context, userID string) ([]models.UserAchievement, error)
+	// Award stores the achievement unless the user already holds the badge, reporting whether it was stored
+	Award(ctx context.Context, achievement *models.UserAchievement) (bool, error)
+}
+
+// YearReviewRepository caches generated year-in-review summaries
+type YearReviewRepository interface {
+	Find(ctx context.Context, userID string, year int) (*models.YearReview, error)
+	// Save stores the review unless one is already cached for the same user and year
+	Save(ctx context.Context, review *models.YearReview) error
+	Delete(ctx context.Context, userID string, year int) error
+	DeleteForUser(ctx context.Context, userID string) error
+}
+
+// WebhookEventRepository stores verified webhook deliveries so they can be inspected and replayed
+type WebhookEventRepository interface {
+	Create(ctx context.Context, event *models.WebhookEvent) error
+	Update(ctx context.Context, event *models.WebhookEvent) error
+	FindByID(ctx context.Context, id uint) (*models.WebhookEvent, error)
+	// List returns up to limit events, newest first
+	List(ctx context.Context, limit int) ([]models.WebhookEvent, error)
+}
+
+// AccessTokenRepository persists personal access tokens
+type AccessTokenRepository interface {
+	Create(ctx context.Context, token *models.AccessToken) error
+	// FindByHash returns the token with the given hash, or ErrNotFound
+	FindByHash(ctx context.Context, hash string) (*models.AccessToken, error)
+	// ListForUser returns the user's tokens, newest first
+	ListForUser(ctx context.Context, userID string) ([]models.AccessToken, error)
+	// Delete revokes one of the user's tokens, returning ErrNotFound if they have no token with that ID
+	Delete(ctx context.Context, userID string, id uint) error
+	MarkUsed(ctx context.Context, id uint, at time.Time) error
+}
+
+// AuditEventRepository stores the append-only account history. Events are never updated.
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *models.AuditEvent) error
+	// ListForUser returns up to limit of the user's events with an ID below beforeID (all when zero), newest ID first
+	ListForUser(ctx context.Context, userID string, beforeID uint, limit int) ([]models.AuditEvent, error)
+}
+
+// PreferencesRepository persists user preferences
+type PreferencesRepository interface {
+	// Get returns the user's preferences, or models.DefaultPreferences if they never saved any
+	Get(ctx context.Context, userID string) (*models.UserPreferences, error)
+	// Save creates or replaces the user's preferences
+	Save(ctx context.Context, preferences *models.UserPreferences) error
+}
+
+// ReminderRepository persists meditation reminders
+type ReminderRepository interface {
+	Create(ctx context.Context, reminder *models.Reminder) error
+	Update(ctx context.Context, reminder *models.Reminder) error
+	// FindForUser returns one of the user's reminders, or ErrNotFound
+	FindForUser(ctx context.Context, userID string, id uint) (*models.Reminder, error)
+	// ListForUser returns the user's reminders ordered by time of day
+	ListForUser(ctx context.Context, userID string) ([]models.Reminder, error)
+	// Delete removes one of the user's reminders, returning ErrNotFound if they have no reminder with that ID
+	Delete(ctx context.Context, userID string, id uint) error
+	// ListEnabled returns every enabled reminder of users who aren't deleted
+	ListEnabled(ctx context.Context) ([]models.Reminder, error)
+	// MarkTriggered claims the occurrence of the reminder due at dueAt, recording at as when it
+	// was handled. It returns false without changing anything if that occurrence was already
+	// claimed, so replicas running the scheduler don't both handle it.
+	MarkTriggered(ctx context.Context, id uint, dueAt, at time.Time) (bool, error)
+}
+
+// StatsRepository answers service-wide questions for operators
+type StatsRepository interface {
+	Totals(ctx context.Context) (Totals, error)
+}
+
+// Repositories bundles every repository the application depends on
+type Repositories struct {
+	Users         UserRepository
+	Sessions      SessionRepository
+	Achievements  AchievementRepository
+	YearReviews   YearReviewRepository
+	WebhookEvents WebhookEventRepository
+	AccessTokens  AccessTokenRepository
+	AuditEvents   AuditEventRepository
+	Preferences   PreferencesRepository
+	Reminders     ReminderRepository
+	Stats         StatsRepository
+}
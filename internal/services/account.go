@@ -0,0 +1,232 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
+)
+
+// exportPageSize is how many audit events are read at a time while exporting
+const exportPageSize = 500
+
+// AccountService exports a user's data and deletes accounts once their grace period has passed
+type AccountService struct {
+	users        repository.UserRepository
+	sessions     repository.SessionRepository
+	achievements repository.AchievementRepository
+	accessTokens repository.AccessTokenRepository
+	auditEvents  repository.AuditEventRepository
+	preferences  repository.PreferencesRepository
+	reminders    repository.ReminderRepository
+	gracePeriod  time.Duration
+	now          func() time.Time
+}
+
+func NewAccountService(repos *repository.Repositories, gracePeriod time.Duration) *AccountService {
+	return NewAccountServiceWithClock(repos, gracePeriod, time.Now)
+}
+
+// NewAccountServiceWithClock returns a service that reads the time from now, for tests that control time
+func NewAccountServiceWithClock(repos *repository.Repositories, gracePeriod time.Duration, now func() time.Time) *AccountService {
+	return &AccountService{
+		users:        repos.Users,
+		sessions:     repos.Sessions,
+		achievements: repos.Achievements,
+		accessTokens: repos.AccessTokens,
+		auditEvents:  repos.AuditEvents,
+		preferences:  repos.Preferences,
+		reminders:    repos.Reminders,
+		gracePeriod:  gracePeriod,
+		now:          now,
+	}
+}
+
+// Export writes a zip archive holding the user's profile, preferences, reminders, sessions (including
+// deleted ones), achievements, access tokens and account history as JSON files. Cached year reviews
+// are left out because they are computed from the sessions.
+func (s *AccountService) Export(ctx context.Context, user *models.User, w io.Writer) error {
+	ctx, span := tracing.Start(ctx, "AccountService.Export")
+	defer span.End()
+
+	preferences, err := s.preferences.Get(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	reminders, err := s.reminders.ListForUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := s.sessions.ListWithDeleted(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	achievements, err := s.achievements.ListForUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := s.accessTokens.ListForUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	var events []models.AuditEvent
+	var beforeID uint
+	for {
+		page, err := s.auditEvents.ListForUser(ctx, user.ID, beforeID, exportPageSize)
+		if err != nil {
+			return err
+		}
+		events = append(events, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+		beforeID = page[len(page)-1].ID
+	}
+
+	archive := zip.NewWriter(w)
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", user},
+		{"preferences.json", preferences},
+		{"reminders.json", nonNil(reminders)},
+		{"sessions.json", nonNil(sessions)},
+		{"achievements.json", nonNil(achievements)},
+		{"access_tokens.json", nonNil(tokens)},
+		{"audit_events.json", nonNil(events)},
+	}
+	for _, file := range files {
+		f, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// ScheduleDeletion records the user's request to delete their account. The account keeps working
+// until it is purged, so the request can be cancelled. Repeated requests keep the original date.
+func (s *AccountService) ScheduleDeletion(ctx context.Context, user *models.User) error {
+	if user.DeletionRequestedAt != nil {
+		return nil
+	}
+
+	requestedAt := s.now()
+	if err := s.users.SetDeletionRequestedAt(ctx, user.ID, &requestedAt); err != nil {
+		return err
+	}
+	user.DeletionRequestedAt = &requestedAt
+
+	return nil
+}
+
+// CancelDeletion withdraws the user's deletion request, if they made one
+func (s *AccountService) CancelDeletion(ctx context.Context, user *models.User) error {
+	if user.DeletionRequestedAt == nil {
+		return nil
+	}
+
+	if err := s.users.SetDeletionRequestedAt(ctx, user.ID, nil); err != nil {
+		return err
+	}
+	user.DeletionRequestedAt = nil
+
+	return nil
+}
+
+// DeleteAfter returns when the user's account will be purged, or nil if no deletion is pending
+func (s *AccountService) DeleteAfter(user *models.User) *time.Time {
+	requestedAt := user.DeletionRequestedAt
+	if requestedAt == nil && user.DeletedAt.Valid {
+		requestedAt = &user.DeletedAt.Time
+	}
+	if requestedAt == nil {
+		return nil
+	}
+
+	deleteAfter := requestedAt.Add(s.gracePeriod)
+
+	return &deleteAfter
+}
+
+// Purge permanently deletes every account whose deletion was requested, or which was soft
+// deleted by the identity provider, more than the grace period ago. It returns how many were
+// deleted; a failure on one account doesn't stop the others.
+func (s *AccountService) Purge(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "AccountService.Purge")
+	defer span.End()
+
+	users, err := s.users.ListDueForPurge(ctx, s.now().Add(-s.gracePeriod))
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	var errs []error
+	for _, user := range users {
+		err := s.users.HardDelete(ctx, user.ID)
+		if errors.Is(err, repository.ErrNotFound) {
+			// Another replica purged it first
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		purged++
+		metrics.AccountPurged()
+		slog.InfoContext(ctx, "purged deleted account", slog.String("user_id", user.ID))
+	}
+
+	return purged, errors.Join(errs...)
+}
+
+// RunPurge calls Purge every interval until ctx is cancelled
+func (s *AccountService) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Purge(ctx); err != nil {
+			slog.ErrorContext(ctx, "failed to purge deleted accounts", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// nonNil turns a nil slice into an empty one so it is exported as [] rather than null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
@@ -0,0 +1,141 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+)
+
+func TestAccountService(t *testing.T) {
+	ctx := context.Background()
+	gracePeriod := 24 * time.Hour
+
+	// setup returns a service over memory repositories holding one user with a deleted session,
+	// with a clock the test moves
+	setup := func() (*services.AccountService, *repository.Repositories, *models.User, *time.Time) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		require.NoError(t, repos.Users.Create(ctx, user))
+		session := testutils.CreateTestSession(user.ID)
+		require.NoError(t, repos.Sessions.Create(ctx, session))
+		require.NoError(t, repos.Sessions.Delete(ctx, session))
+
+		now := time.Now()
+		accounts := services.NewAccountServiceWithClock(repos, gracePeriod, func() time.Time { return now })
+
+		return accounts, repos, user, &now
+	}
+
+	t.Run("export every file with the user's data", func(t *testing.T) {
+		accounts, _, user, _ := setup()
+
+		var archive bytes.Buffer
+		require.NoError(t, accounts.Export(ctx, user, &archive))
+
+		reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+		require.NoError(t, err)
+		files := make(map[string]string)
+		for _, f := range reader.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			require.NoError(t, rc.Close())
+			files[f.Name] = string(data)
+		}
+
+		assert.Len(t, files, 7)
+		assert.Contains(t, files["profile.json"], user.ID)
+		assert.Contains(t, files["preferences.json"], `"timezone": "UTC"`)
+		var sessions []models.Session
+		require.NoError(t, json.Unmarshal([]byte(files["sessions.json"]), &sessions))
+		assert.Len(t, sessions, 1)
+		assert.JSONEq(t, `[]`, files["access_tokens.json"])
+		assert.JSONEq(t, `[]`, files["reminders.json"])
+	})
+
+	t.Run("keep the first request date when deletion is requested again", func(t *testing.T) {
+		accounts, _, user, now := setup()
+
+		require.NoError(t, accounts.ScheduleDeletion(ctx, user))
+		requestedAt := *user.DeletionRequestedAt
+		*now = now.Add(time.Hour)
+		require.NoError(t, accounts.ScheduleDeletion(ctx, user))
+
+		assert.Equal(t, requestedAt, *user.DeletionRequestedAt)
+		assert.Equal(t, requestedAt.Add(gracePeriod), *accounts.DeleteAfter(user))
+	})
+
+	t.Run("leave other fields alone when the user is stale", func(t *testing.T) {
+		accounts, repos, user, _ := setup()
+		stale := *user
+		user.Email = "new@example.com"
+		require.NoError(t, repos.Users.Update(ctx, user))
+
+		require.NoError(t, accounts.ScheduleDeletion(ctx, &stale))
+
+		stored, err := repos.Users.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "new@example.com", stored.Email)
+		assert.NotNil(t, stored.DeletionRequestedAt)
+	})
+
+	t.Run("purge accounts once their grace period has passed", func(t *testing.T) {
+		accounts, repos, user, now := setup()
+		deleted := testutils.CreateTestUser("user_456")
+		require.NoError(t, repos.Users.Create(ctx, deleted))
+		require.NoError(t, repos.Users.DeleteByClerkUserID(ctx, "user_456"))
+		kept := testutils.CreateTestUser("user_789")
+		require.NoError(t, repos.Users.Create(ctx, kept))
+		require.NoError(t, accounts.ScheduleDeletion(ctx, user))
+
+		*now = now.Add(gracePeriod - time.Minute)
+		purged, err := accounts.Purge(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+
+		*now = now.Add(2 * time.Minute)
+		purged, err = accounts.Purge(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, purged)
+
+		remaining, err := repos.Users.Search(ctx, repository.UserSearch{Email: "test@example.com"})
+		require.NoError(t, err)
+		require.Len(t, remaining, 1)
+		assert.Equal(t, kept.ID, remaining[0].ID)
+		sessions, err := repos.Sessions.ListWithDeleted(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+
+	t.Run("keep accounts whose deletion was cancelled or restored", func(t *testing.T) {
+		accounts, repos, user, now := setup()
+		deleted := testutils.CreateTestUser("user_456")
+		require.NoError(t, repos.Users.Create(ctx, deleted))
+		require.NoError(t, repos.Users.DeleteByClerkUserID(ctx, "user_456"))
+		require.NoError(t, accounts.ScheduleDeletion(ctx, user))
+
+		require.NoError(t, accounts.CancelDeletion(ctx, user))
+		_, err := repos.Users.Restore(ctx, deleted.ID)
+		require.NoError(t, err)
+
+		*now = now.Add(2 * gracePeriod)
+		purged, err := accounts.Purge(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+		assert.Nil(t, accounts.DeleteAfter(user))
+	})
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/constants"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
+)
+
+// earlyMorningHour is the hour before which a session counts as an early morning session
+const earlyMorningHour = 6
+
+// AchievementStats is the snapshot of a user's history that badge rules are evaluated against
+type AchievementStats struct {
+	TotalSessions        int
+	TotalMinutes         int
+	LongestStreak        int
+	SessionTypesTried    int
+	EarlyMorningSessions int
+}
+
+// Badge defines a single achievement and the rule used to measure progress towards it
+type Badge struct {
+	Key         string
+	Name        string
+	Description string
+	Target      int
+	Progress    func(stats AchievementStats) int
+}
+
+type AchievementProgress struct {
+	Key         string     `json:"key"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Earned      bool       `json:"earned"`
+	Progress    int        `json:"progress"`
+	Target      int        `json:"target"`
+	AwardedAt   *time.Time `json:"awarded_at,omitempty"`
+	SessionID   *uint      `json:"session_id,omitempty"`
+}
+
+// Badges lists every achievement a user can earn, in display order
+var Badges = []Badge{
+	{
+		Key:         "first_session",
+		Name:        "First Steps",
+		Description: "Complete your first meditation session",
+		Target:      1,
+		Progress:    func(s AchievementStats) int { return s.TotalSessions },
+	},
+	{
+		Key:         "streak_7",
+		Name:        "7-Day Streak",
+		Description: "Meditate seven days in a row",
+		Target:      7,
+		Progress:    func(s AchievementStats) int { return s.LongestStreak },
+	},
+	{
+		Key:         "streak_30",
+		Name:        "30-Day Streak",
+		Description: "Meditate thirty days in a row",
+		Target:      30,
+		Progress:    func(s AchievementStats) int { return s.LongestStreak },
+	},
+	{
+		Key:         "explorer",
+		Name:        "Explorer",
+		Description: "Try every session type",
+		Target:      len(constants.SessionTypes),
+		Progress:    func(s AchievementStats) int { return s.SessionTypesTried },
+	},
+	{
+		Key:         "early_bird",
+		Name:        "Early Bird",
+		Description: "Meditate before 6am ten times",
+		Target:      10,
+		Progress:    func(s AchievementStats) int { return s.EarlyMorningSessions },
+	},
+	{
+		Key:         "sessions_100",
+		Name:        "Centurion",
+		Description: "Complete 100 sessions",
+		Target:      100,
+		Progress:    func(s AchievementStats) int { return s.TotalSessions },
+	},
+	{
+		Key:         "hours_10",
+		Name:        "Ten Hours",
+		Description: "Meditate for ten hours in total",
+		Target:      600,
+		Progress:    func(s AchievementStats) int { return s.TotalMinutes },
+	},
+}
+
+// AchievementService evaluates badge rules and tracks which badges users hold
+type AchievementService struct {
+	sessions     repository.SessionRepository
+	achievements repository.AchievementRepository
+	preferences  repository.PreferencesRepository
+}
+
+func NewAchievementService(sessions repository.SessionRepository, achievements repository.AchievementRepository, preferences repository.PreferencesRepository) *AchievementService {
+	return &AchievementService{sessions: sessions, achievements: achievements, preferences: preferences}
+}
+
+// GetAchievementStats gathers the statistics badge rules are evaluated against
+func (s *AchievementService) GetAchievementStats(ctx context.Context, userID string) (AchievementStats, error) {
+	ctx, span := tracing.Start(ctx, "AchievementService.GetAchievementStats")
+	defer span.End()
+
+	// Days and hours follow the user's timezone. Awarded badges are kept, so changing timezone
+	// can earn a badge but never takes one away.
+	cal, err := loadCalendar(ctx, s.preferences, userID)
+	if err != nil {
+		return AchievementStats{}, err
+	}
+
+	totals, err := s.sessions.DailyTotals(ctx, userID, time.Time{}, time.Time{}, cal.loc)
+	if err != nil {
+		return AchievementStats{}, err
+	}
+
+	var stats AchievementStats
+	var totalSeconds int
+	for _, total := range totals {
+		stats.TotalSessions += total.Sessions
+		totalSeconds += total.Seconds
+	}
+	stats.TotalMinutes = totalSeconds / 60
+	stats.LongestStreak = longestStreakRecord(totals).Days
+
+	typeTotals, err := s.sessions.TypeTotals(ctx, userID, time.Time{}, time.Time{})
+	if err != nil {
+		return AchievementStats{}, err
+	}
+	stats.SessionTypesTried = len(typeTotals)
+
+	stats.EarlyMorningSessions, err = s.sessions.CountStartedBeforeHour(ctx, userID, earlyMorningHour, cal.loc)
+	if err != nil {
+		return AchievementStats{}, err
+	}
+
+	return stats, nil
+}
+
+// EvaluateAchievements awards any newly earned badges to the user, crediting the given session if any.
+// Badges already held are left untouched; only badges awarded by this call are returned.
+func (s *AchievementService) EvaluateAchievements(ctx context.Context, userID string, session *models.Session) ([]models.UserAchievement, error) {
+	ctx, span := tracing.Start(ctx, "AchievementService.EvaluateAchievements")
+	defer span.End()
+
+	stats, err := s.GetAchievementStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	earned, err := s.getEarnedAchievements(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessionID *uint
+	if session != nil {
+		sessionID = &session.ID
+	}
+
+	var awarded []models.UserAchievement
+	for _, badge := range Badges {
+		if _, ok := earned[badge.Key]; ok || badge.Progress(stats) < badge.Target {
+			continue
+		}
+
+		achievement := models.UserAchievement{
+			UserID:    userID,
+			BadgeKey:  badge.Key,
+			SessionID: sessionID,
+			AwardedAt: time.Now(),
+		}
+
+		stored, err := s.achievements.Award(ctx, &achievement)
+		if err != nil {
+			return nil, err
+		}
+
+		if stored {
+			awarded = append(awarded, achievement)
+		}
+	}
+
+	return awarded, nil
+}
+
+// GetAchievements lists every badge with the user's progress towards it
+func (s *AchievementService) GetAchievements(ctx context.Context, userID string) ([]AchievementProgress, error) {
+	ctx, span := tracing.Start(ctx, "AchievementService.GetAchievements")
+	defer span.End()
+
+	stats, err := s.GetAchievementStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	earned, err := s.getEarnedAchievements(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]AchievementProgress, 0, len(Badges))
+	for _, badge := range Badges {
+		item := AchievementProgress{
+			Key:         badge.Key,
+			Name:        badge.Name,
+			Description: badge.Description,
+			Progress:    min(badge.Progress(stats), badge.Target),
+			Target:      badge.Target,
+		}
+
+		if achievement, ok := earned[badge.Key]; ok {
+			item.Earned = true
+			item.Progress = badge.Target
+			item.AwardedAt = &achievement.AwardedAt
+			item.SessionID = achievement.SessionID
+		}
+
+		progress = append(progress, item)
+	}
+
+	return progress, nil
+}
+
+// getEarnedAchievements returns the user's awarded badges keyed by badge key
+func (s *AchievementService) getEarnedAchievements(ctx context.Context, userID string) (map[string]models.UserAchievement, error) {
+	achievements, err := s.achievements.ListForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	earned := make(map[string]models.UserAchievement, len(achievements))
+	for _, achievement := range achievements {
+		earned[achievement.BadgeKey] = achievement
+	}
+
+	return earned, nil
+}
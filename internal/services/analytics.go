@@ -1,10 +1,13 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"time"
 
-	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
 	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
 )
 
 type StreakInfo struct {
@@ -25,22 +28,83 @@ type YearlyProgress struct {
 }
 
 type DashboardData struct {
-	User            models.User      `json:"user"`
-	Streaks         StreakInfo       `json:"streaks"`
-	WeeklyProgress  []WeeklyProgress `json:"weekly_progress"`
-	YearlyProgress  []YearlyProgress `json:"yearly_progress"`
-	RecentSessions  []models.Session `json:"recent_sessions"`
+	User           models.User      `json:"user"`
+	Streaks        StreakInfo       `json:"streaks"`
+	WeeklyProgress []WeeklyProgress `json:"weekly_progress"`
+	YearlyProgress []YearlyProgress `json:"yearly_progress"`
+	RecentSessions []models.Session `json:"recent_sessions"`
+	Records        *Records         `json:"records"`
+	Trends         *Trends          `json:"trends"`
 }
 
-// CalculateStreaks calculates current and longest streak for a user using efficient SQL queries
-func CalculateStreaks(userID string) (StreakInfo, error) {
-	sessionDates, err := getSessionDates(userID)
+// AnalyticsService computes dashboard statistics from a user's sessions. Days and weeks follow
+// the user's timezone and week start preferences.
+type AnalyticsService struct {
+	sessions    repository.SessionRepository
+	preferences repository.PreferencesRepository
+}
+
+func NewAnalyticsService(sessions repository.SessionRepository, preferences repository.PreferencesRepository) *AnalyticsService {
+	return &AnalyticsService{sessions: sessions, preferences: preferences}
+}
+
+// calendar is how a user divides time into days and weeks
+type calendar struct {
+	loc       *time.Location
+	weekStart time.Weekday
+}
+
+// now returns the current time in the user's timezone
+func (c calendar) now() time.Time {
+	return time.Now().In(c.loc)
+}
+
+// startOfWeek returns the first day of the user's week containing t
+func (c calendar) startOfWeek(t time.Time) time.Time {
+	return startOfWeek(t, c.weekStart)
+}
+
+// calendarFor returns the calendar set in the user's preferences
+func calendarFor(preferences *models.UserPreferences) calendar {
+	return calendar{loc: preferences.Location(), weekStart: preferences.FirstWeekday()}
+}
+
+// calendar reads the user's calendar from their preferences
+func (s *AnalyticsService) calendar(ctx context.Context, userID string) (calendar, error) {
+	return loadCalendar(ctx, s.preferences, userID)
+}
+
+// loadCalendar reads the user's calendar from their stored preferences
+func loadCalendar(ctx context.Context, preferences repository.PreferencesRepository, userID string) (calendar, error) {
+	stored, err := preferences.Get(ctx, userID)
+	if err != nil {
+		return calendar{}, err
+	}
+
+	return calendarFor(stored), nil
+}
+
+// CalculateStreaks calculates current and longest streak for a user from their daily totals
+func (s *AnalyticsService) CalculateStreaks(ctx context.Context, userID string) (StreakInfo, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.CalculateStreaks")
+	defer span.End()
+
+	cal, err := s.calendar(ctx, userID)
+	if err != nil {
+		return StreakInfo{}, err
+	}
+
+	return s.calculateStreaks(ctx, userID, cal)
+}
+
+func (s *AnalyticsService) calculateStreaks(ctx context.Context, userID string, cal calendar) (StreakInfo, error) {
+	sessionDates, err := s.getSessionDates(ctx, userID, cal)
 	if err != nil {
 		return StreakInfo{}, err
 	}
 
 	longestStreak := calculateLongestStreak(sessionDates)
-	currentStreak := calculateCurrentStreak(sessionDates)
+	currentStreak := calculateCurrentStreak(sessionDates, cal.now())
 
 	return StreakInfo{
 		Current: currentStreak,
@@ -64,11 +128,11 @@ func calculateLongestStreak(sessionDates []string) int {
 	// Calculate longest streak from session dates
 	longestStreak := 1
 	currentStreak := 1
-	
+
 	for i := 1; i < len(ascending); i++ {
 		prevDate, _ := time.Parse("2006-01-02", ascending[i-1])
 		currDate, _ := time.Parse("2006-01-02", ascending[i])
-		
+
 		// Check if dates are consecutive
 		if currDate.Sub(prevDate).Hours() == 24 {
 			currentStreak++
@@ -79,23 +143,24 @@ func calculateLongestStreak(sessionDates []string) int {
 			currentStreak = 1
 		}
 	}
-	
+
 	if currentStreak > longestStreak {
 		longestStreak = currentStreak
 	}
-	
+
 	return longestStreak
 }
 
 // calculateCurrentStreak calculates current streak from session dates (already in DESC order)
-func calculateCurrentStreak(sessionDates []string) int {
+// ending today or yesterday relative to now
+func calculateCurrentStreak(sessionDates []string, now time.Time) int {
 	if len(sessionDates) == 0 {
 		return 0
 	}
 
-	today := time.Now().Format("2006-01-02")
-	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
-	
+	today := now.Format("2006-01-02")
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
+
 	// Check if we should start counting from today or yesterday
 	var startDate string
 	switch sessionDates[0] {
@@ -111,10 +176,10 @@ func calculateCurrentStreak(sessionDates []string) int {
 	// Count consecutive days backwards from start date
 	currentStreak := 0
 	expectedDate, _ := time.Parse("2006-01-02", startDate)
-	
+
 	for _, dateStr := range sessionDates {
 		sessionDate, _ := time.Parse("2006-01-02", dateStr)
-		
+
 		// Check if this date matches our expected consecutive date
 		if sessionDate.Format("2006-01-02") == expectedDate.Format("2006-01-02") {
 			currentStreak++
@@ -124,31 +189,46 @@ func calculateCurrentStreak(sessionDates []string) int {
 			break
 		}
 	}
-	
+
 	return currentStreak
 }
 
-
 // GetWeeklyProgress gets the last 7 days of meditation progress
-func GetWeeklyProgress(userID string) ([]WeeklyProgress, error) {
+func (s *AnalyticsService) GetWeeklyProgress(ctx context.Context, userID string) ([]WeeklyProgress, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetWeeklyProgress")
+	defer span.End()
+
+	cal, err := s.calendar(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getWeeklyProgress(ctx, userID, cal)
+}
+
+func (s *AnalyticsService) getWeeklyProgress(ctx context.Context, userID string, cal calendar) ([]WeeklyProgress, error) {
+	now := cal.now()
+	firstDay := startOfDay(now.AddDate(0, 0, -6))
+
+	totals, err := s.sessions.DailyTotals(ctx, userID, firstDay, time.Time{}, cal.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	secondsByDate := make(map[string]int, len(totals))
+	for _, total := range totals {
+		secondsByDate[total.Day.Format("2006-01-02")] += total.Seconds
+	}
+
 	var progress []WeeklyProgress
 
 	// Get last 7 days
 	for i := 6; i >= 0; i-- {
-		date := time.Now().AddDate(0, 0, -i)
+		date := now.AddDate(0, 0, -i)
 		dateStr := date.Format("2006-01-02")
 		dayName := date.Format("Mon")
 
-		var totalSeconds int
-		err := database.DB.Model(&models.Session{}).
-			Where("user_id = ? AND DATE(created_at) = ? AND deleted_at IS NULL", userID, dateStr).
-			Select("COALESCE(SUM(duration_seconds), 0)").
-			Scan(&totalSeconds).Error
-		if err != nil {
-			return nil, err
-		}
-		
-		totalMinutes := totalSeconds / 60
+		totalMinutes := secondsByDate[dateStr] / 60
 
 		progress = append(progress, WeeklyProgress{
 			Day:     dayName,
@@ -161,26 +241,38 @@ func GetWeeklyProgress(userID string) ([]WeeklyProgress, error) {
 }
 
 // GetYearlyProgress gets monthly meditation progress for the specified year
-func GetYearlyProgress(userID string, year int) ([]YearlyProgress, error) {
+func (s *AnalyticsService) GetYearlyProgress(ctx context.Context, userID string, year int) ([]YearlyProgress, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetYearlyProgress")
+	defer span.End()
+
+	cal, err := s.calendar(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getYearlyProgress(ctx, userID, year, cal)
+}
+
+func (s *AnalyticsService) getYearlyProgress(ctx context.Context, userID string, year int, cal calendar) ([]YearlyProgress, error) {
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, cal.loc)
+
+	totals, err := s.sessions.DailyTotals(ctx, userID, yearStart, yearStart.AddDate(1, 0, 0), cal.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	var secondsByMonth [12]int
+	for _, total := range totals {
+		secondsByMonth[total.Day.Month()-1] += total.Seconds
+	}
+
 	var progress []YearlyProgress
 
 	months := []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun",
 		"Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
 
 	for i, month := range months {
-		monthStart := time.Date(year, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC)
-		monthEnd := monthStart.AddDate(0, 1, 0).Add(-time.Second)
-
-		var totalSeconds int
-		err := database.DB.Model(&models.Session{}).
-			Where("user_id = ? AND created_at >= ? AND created_at <= ? AND deleted_at IS NULL",
-				userID, monthStart, monthEnd).
-			Select("COALESCE(SUM(duration_seconds), 0)").
-			Scan(&totalSeconds).Error
-		if err != nil {
-			return nil, err
-		}
-
+		totalSeconds := secondsByMonth[i]
 		minutes := totalSeconds / 60
 		hours := float64(totalSeconds) / 3600.0
 
@@ -195,66 +287,96 @@ func GetYearlyProgress(userID string, year int) ([]YearlyProgress, error) {
 }
 
 // GetRecentSessions gets recent sessions for a user with configurable limit
-func GetRecentSessions(userID string, limit int) ([]models.Session, error) {
-	var sessions []models.Session
+func (s *AnalyticsService) GetRecentSessions(ctx context.Context, userID string, limit int) ([]models.Session, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetRecentSessions")
+	defer span.End()
 
 	// Set default limit if not provided or invalid
 	if limit <= 0 || limit > 100 {
 		limit = 5
 	}
 
-	err := database.DB.Where("user_id = ? AND deleted_at IS NULL", userID).
-		Order("created_at DESC").
-		Limit(limit).
-		Find(&sessions).Error
-
-	return sessions, err
+	return s.sessions.Recent(ctx, userID, limit)
 }
 
 // GetDashboardData aggregates all dashboard data for a user with configurable parameters
-func GetDashboardData(user *models.User, year int, sessionLimit int) (*DashboardData, error) {
-	streaks, err := CalculateStreaks(user.ID)
+func (s *AnalyticsService) GetDashboardData(ctx context.Context, user *models.User, year int, sessionLimit int) (*DashboardData, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetDashboardData")
+	defer span.End()
+
+	// Read the preferences once rather than in every section
+	cal, err := s.calendar(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	weeklyProgress, err := GetWeeklyProgress(user.ID)
+	streaks, err := s.calculateStreaks(ctx, user.ID, cal)
+	if err != nil {
+		return nil, err
+	}
+
+	weeklyProgress, err := s.getWeeklyProgress(ctx, user.ID, cal)
 	if err != nil {
 		return nil, err
 	}
 
 	// Default to current year if not provided
 	if year <= 0 {
-		year = time.Now().Year()
+		year = cal.now().Year()
+	}
+
+	yearlyProgress, err := s.getYearlyProgress(ctx, user.ID, year, cal)
+	if err != nil {
+		return nil, err
+	}
+
+	recentSessions, err := s.GetRecentSessions(ctx, user.ID, sessionLimit)
+	if err != nil {
+		return nil, err
 	}
 
-	yearlyProgress, err := GetYearlyProgress(user.ID, year)
+	records, err := s.getRecords(ctx, user.ID, cal)
 	if err != nil {
 		return nil, err
 	}
 
-	recentSessions, err := GetRecentSessions(user.ID, sessionLimit)
+	trends, err := s.getTrends(ctx, user.ID, cal)
 	if err != nil {
 		return nil, err
 	}
 
 	return &DashboardData{
-		User:            *user,
-		Streaks:         streaks,
-		WeeklyProgress:  weeklyProgress,
-		YearlyProgress:  yearlyProgress,
-		RecentSessions:  recentSessions,
+		User:           *user,
+		Streaks:        streaks,
+		WeeklyProgress: weeklyProgress,
+		YearlyProgress: yearlyProgress,
+		RecentSessions: recentSessions,
+		Records:        records,
+		Trends:         trends,
 	}, nil
 }
 
 // getSessionDates retrieves distinct session dates for a user in descending order
-func getSessionDates(userID string) ([]string, error) {
-	var sessionDates []string
-	err := database.DB.Model(&models.Session{}).
-		Where("user_id = ? AND deleted_at IS NULL", userID).
-		Select("DISTINCT DATE(created_at) as session_date").
-		Order("session_date DESC").
-		Pluck("session_date", &sessionDates).Error
-	
-	return sessionDates, err
-}
\ No newline at end of file
+func (s *AnalyticsService) getSessionDates(ctx context.Context, userID string, cal calendar) ([]string, error) {
+	totals, err := s.sessions.DailyTotals(ctx, userID, time.Time{}, time.Time{}, cal.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionDates := make([]string, len(totals))
+	for i, total := range totals {
+		sessionDates[len(totals)-1-i] = total.Day.Format("2006-01-02")
+	}
+
+	return sessionDates, nil
+}
+
+// longestSession returns the longest session in the range, or nil if there is none
+func longestSession(ctx context.Context, sessions repository.SessionRepository, userID string, from, to time.Time) (*models.Session, error) {
+	session, err := sessions.Longest(ctx, userID, from, to)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+
+	return session, err
+}
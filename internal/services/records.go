@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
+)
+
+// Milestone thresholds users are celebrated for reaching
+var (
+	sessionMilestones = []int{1, 10, 25, 50, 100, 250, 500, 1000}
+	hourMilestones    = []int{1, 10, 25, 50, 100, 250, 500, 1000}
+)
+
+type PeriodRecord struct {
+	PeriodStart string `json:"period_start,omitempty"`
+	Minutes     int    `json:"minutes"`
+}
+
+type StreakRecord struct {
+	Days      int    `json:"days"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+type PersonalRecords struct {
+	LongestSession     *models.Session `json:"longest_session"`
+	MostMinutesInDay   PeriodRecord    `json:"most_minutes_in_day"`
+	MostMinutesInWeek  PeriodRecord    `json:"most_minutes_in_week"`
+	MostMinutesInMonth PeriodRecord    `json:"most_minutes_in_month"`
+	LongestStreak      StreakRecord    `json:"longest_streak"`
+}
+
+type Milestone struct {
+	Threshold  int    `json:"threshold"`
+	Achieved   bool   `json:"achieved"`
+	AchievedOn string `json:"achieved_on,omitempty"`
+}
+
+type LifetimeMilestones struct {
+	TotalSessions     int         `json:"total_sessions"`
+	TotalMinutes      int         `json:"total_minutes"`
+	TotalHours        float64     `json:"total_hours"`
+	SessionMilestones []Milestone `json:"session_milestones"`
+	HourMilestones    []Milestone `json:"hour_milestones"`
+}
+
+type Records struct {
+	PersonalRecords PersonalRecords    `json:"personal_records"`
+	Milestones      LifetimeMilestones `json:"milestones"`
+}
+
+// GetRecords computes personal records and lifetime milestones for a user
+func (s *AnalyticsService) GetRecords(ctx context.Context, userID string) (*Records, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetRecords")
+	defer span.End()
+
+	cal, err := s.calendar(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getRecords(ctx, userID, cal)
+}
+
+func (s *AnalyticsService) getRecords(ctx context.Context, userID string, cal calendar) (*Records, error) {
+	totals, err := s.sessions.DailyTotals(ctx, userID, time.Time{}, time.Time{}, cal.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	longest, err := longestSession(ctx, s.sessions, userID, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Records{
+		PersonalRecords: PersonalRecords{
+			LongestSession:     longest,
+			MostMinutesInDay:   maxPeriodTotal(totals, startOfDay),
+			MostMinutesInWeek:  maxPeriodTotal(totals, cal.startOfWeek),
+			MostMinutesInMonth: maxPeriodTotal(totals, startOfMonth),
+			LongestStreak:      longestStreakRecord(totals),
+		},
+		Milestones: calculateMilestones(totals),
+	}, nil
+}
+
+// maxPeriodTotal groups daily totals into periods and returns the period with the most minutes
+func maxPeriodTotal(totals []repository.DailyTotal, periodStart func(time.Time) time.Time) PeriodRecord {
+	secondsByPeriod := make(map[time.Time]int)
+	for _, total := range totals {
+		secondsByPeriod[periodStart(total.Day)] += total.Seconds
+	}
+
+	var best PeriodRecord
+	var bestStart time.Time
+	for start, seconds := range secondsByPeriod {
+		minutes := seconds / 60
+		// Ties go to the earliest period so results are stable
+		if minutes > best.Minutes || (minutes == best.Minutes && minutes > 0 && start.Before(bestStart)) {
+			best = PeriodRecord{PeriodStart: start.Format("2006-01-02"), Minutes: minutes}
+			bestStart = start
+		}
+	}
+
+	return best
+}
+
+// longestStreakRecord finds the longest run of consecutive days along with its date range
+func longestStreakRecord(totals []repository.DailyTotal) StreakRecord {
+	if len(totals) == 0 {
+		return StreakRecord{}
+	}
+
+	best := StreakRecord{Days: 1, StartDate: totals[0].Day.Format("2006-01-02"), EndDate: totals[0].Day.Format("2006-01-02")}
+	runStart := 0
+
+	for i := 1; i < len(totals); i++ {
+		if !startOfDay(totals[i].Day).Equal(startOfDay(totals[i-1].Day).AddDate(0, 0, 1)) {
+			runStart = i
+		}
+
+		if days := i - runStart + 1; days > best.Days {
+			best = StreakRecord{
+				Days:      days,
+				StartDate: totals[runStart].Day.Format("2006-01-02"),
+				EndDate:   totals[i].Day.Format("2006-01-02"),
+			}
+		}
+	}
+
+	return best
+}
+
+// calculateMilestones derives lifetime totals and the day each milestone was crossed
+func calculateMilestones(totals []repository.DailyTotal) LifetimeMilestones {
+	sessionMarks := make([]Milestone, len(sessionMilestones))
+	for i, threshold := range sessionMilestones {
+		sessionMarks[i] = Milestone{Threshold: threshold}
+	}
+
+	hourMarks := make([]Milestone, len(hourMilestones))
+	for i, threshold := range hourMilestones {
+		hourMarks[i] = Milestone{Threshold: threshold}
+	}
+
+	var totalSessions, totalSeconds int
+	for _, total := range totals {
+		totalSessions += total.Sessions
+		totalSeconds += total.Seconds
+		day := total.Day.Format("2006-01-02")
+
+		for i := range sessionMarks {
+			if !sessionMarks[i].Achieved && totalSessions >= sessionMarks[i].Threshold {
+				sessionMarks[i].Achieved = true
+				sessionMarks[i].AchievedOn = day
+			}
+		}
+
+		for i := range hourMarks {
+			if !hourMarks[i].Achieved && totalSeconds >= hourMarks[i].Threshold*3600 {
+				hourMarks[i].Achieved = true
+				hourMarks[i].AchievedOn = day
+			}
+		}
+	}
+
+	return LifetimeMilestones{
+		TotalSessions:     totalSessions,
+		TotalMinutes:      totalSeconds / 60,
+		TotalHours:        float64(totalSeconds) / 3600.0,
+		SessionMilestones: sessionMarks,
+		HourMilestones:    hourMarks,
+	}
+}
+
+// startOfDay truncates a time to midnight in its own location
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the first day, falling on weekStart, of the week containing t
+func startOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
+
+// startOfMonth returns the first day of the month containing t
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
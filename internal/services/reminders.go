@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/metrics"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/notify"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
+)
+
+// reminderWindow is how late a reminder may still be sent, e.g. after a restart. Older
+// occurrences are dropped rather than nudging the user at a time they didn't choose.
+const reminderWindow = 15 * time.Minute
+
+// Outcomes of a due reminder, reported in metrics
+const (
+	reminderSent       = "sent"
+	reminderMeditated  = "meditated"
+	reminderQuietHours = "quiet_hours"
+	reminderFailed     = "failed"
+)
+
+// ReminderService sends users' meditation reminders when they come due
+type ReminderService struct {
+	reminders   repository.ReminderRepository
+	preferences repository.PreferencesRepository
+	sessions    repository.SessionRepository
+	analytics   *AnalyticsService
+	dispatcher  notify.Dispatcher
+	now         func() time.Time
+}
+
+func NewReminderService(repos *repository.Repositories, dispatcher notify.Dispatcher) *ReminderService {
+	return NewReminderServiceWithClock(repos, dispatcher, time.Now)
+}
+
+// NewReminderServiceWithClock returns a service that reads the time from now, for tests that control time
+func NewReminderServiceWithClock(repos *repository.Repositories, dispatcher notify.Dispatcher, now func() time.Time) *ReminderService {
+	return &ReminderService{
+		reminders:   repos.Reminders,
+		preferences: repos.Preferences,
+		sessions:    repos.Sessions,
+		analytics:   NewAnalyticsService(repos.Sessions, repos.Preferences),
+		dispatcher:  dispatcher,
+		now:         now,
+	}
+}
+
+// SendDue handles every enabled reminder that came due within the reminder window for users
+// who opted in to reminders. A reminder is skipped if the user already meditated that day or
+// it falls within their quiet hours. It returns how many notifications were sent; a failure
+// for one reminder doesn't stop the others.
+func (s *ReminderService) SendDue(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.SendDue")
+	defer span.End()
+
+	reminders, err := s.reminders.ListEnabled(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	preferencesByUser := make(map[string]*models.UserPreferences)
+	sent := 0
+	var errs []error
+	for i := range reminders {
+		reminder := &reminders[i]
+
+		preferences, cached := preferencesByUser[reminder.UserID]
+		if !cached {
+			preferences, err = s.preferences.Get(ctx, reminder.UserID)
+			if err != nil {
+				errs = append(errs, err)
+
+				continue
+			}
+			preferencesByUser[reminder.UserID] = preferences
+		}
+		if !preferences.RemindersEnabled {
+			continue
+		}
+
+		dueAt, due := reminderDueAt(reminder, now.In(preferences.Location()))
+		if !due {
+			continue
+		}
+
+		outcome, err := s.trigger(ctx, reminder, preferences, dueAt, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reminder %d: %w", reminder.ID, err))
+		}
+		if outcome == reminderSent {
+			sent++
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// trigger claims the occurrence due at dueAt and sends it unless it should be skipped. It
+// returns the outcome, or an empty one if another replica claimed the occurrence first.
+func (s *ReminderService) trigger(ctx context.Context, reminder *models.Reminder, preferences *models.UserPreferences, dueAt, now time.Time) (string, error) {
+	claimed, err := s.reminders.MarkTriggered(ctx, reminder.ID, dueAt, now)
+	if err != nil || !claimed {
+		return "", err
+	}
+
+	outcome, err := s.send(ctx, reminder, preferences, dueAt, now)
+	metrics.Reminder(outcome)
+
+	return outcome, err
+}
+
+func (s *ReminderService) send(ctx context.Context, reminder *models.Reminder, preferences *models.UserPreferences, dueAt, now time.Time) (string, error) {
+	cal := calendarFor(preferences)
+	if preferences.InQuietHours(now.In(cal.loc)) {
+		return reminderQuietHours, nil
+	}
+
+	day := startOfDay(dueAt)
+	totals, err := s.sessions.DailyTotals(ctx, reminder.UserID, day.UTC(), day.AddDate(0, 0, 1).UTC(), cal.loc)
+	if err != nil {
+		return reminderFailed, err
+	}
+	if len(totals) > 0 {
+		return reminderMeditated, nil
+	}
+
+	notification := notify.Notification{
+		UserID:     reminder.UserID,
+		Kind:       notify.KindReminder,
+		Title:      "Time to meditate",
+		Body:       reminderBody(preferences),
+		Locale:     preferences.Locale,
+		ReminderID: reminder.ID,
+	}
+
+	if preferences.StreakRemindersEnabled {
+		// The user hasn't meditated on the due day, so the streak runs up to the day before
+		dates, err := s.analytics.getSessionDates(ctx, reminder.UserID, cal)
+		if err != nil {
+			return reminderFailed, err
+		}
+		if streak := calculateCurrentStreak(dates, dueAt); streak > 0 {
+			notification.StreakDays = streak
+			notification.Body = fmt.Sprintf("Meditate today to keep your %d-day streak going.", streak)
+		}
+	}
+
+	if err := s.dispatcher.Dispatch(ctx, notification); err != nil {
+		return reminderFailed, err
+	}
+
+	slog.InfoContext(ctx, "sent reminder", slog.String("user_id", reminder.UserID), slog.Uint64("reminder_id", uint64(reminder.ID)))
+
+	return reminderSent, nil
+}
+
+// Run calls SendDue every interval until ctx is cancelled
+func (s *ReminderService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.SendDue(ctx); err != nil {
+			slog.ErrorContext(ctx, "failed to send reminders", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reminderDueAt returns the most recent occurrence of the reminder at or before now, in now's
+// location, if it is within the reminder window. Yesterday is checked too so a reminder just
+// before midnight isn't lost to a tick just after it.
+func reminderDueAt(reminder *models.Reminder, now time.Time) (time.Time, bool) {
+	localTime, err := time.Parse("15:04", reminder.LocalTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	for _, daysAgo := range []int{0, 1} {
+		day := now.AddDate(0, 0, -daysAgo)
+		if !reminder.OnDay(day.Weekday()) {
+			continue
+		}
+
+		dueAt := time.Date(day.Year(), day.Month(), day.Day(), localTime.Hour(), localTime.Minute(), 0, 0, now.Location())
+		if !dueAt.After(now) && now.Sub(dueAt) <= reminderWindow {
+			return dueAt, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// reminderBody suggests the user's default session
+func reminderBody(preferences *models.UserPreferences) string {
+	minutes := preferences.DefaultDurationSeconds / 60
+	unit := "minutes"
+	if minutes == 1 {
+		unit = "minute"
+	}
+
+	sessionType := strings.ReplaceAll(preferences.DefaultSessionType, "_", " ")
+
+	return fmt.Sprintf("Take %d %s for a %s session.", minutes, unit, sessionType)
+}
@@ -0,0 +1,193 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/notify"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository/memory"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/services"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/testutils"
+)
+
+// recordingDispatcher keeps the notifications it is given, failing with err if set
+type recordingDispatcher struct {
+	sent []notify.Notification
+	err  error
+}
+
+func (d *recordingDispatcher) Dispatch(_ context.Context, notification notify.Notification) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.sent = append(d.sent, notification)
+
+	return nil
+}
+
+func TestReminderService(t *testing.T) {
+	ctx := context.Background()
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// A Wednesday
+	dueAt := time.Date(2025, 3, 12, 7, 30, 0, 0, berlin)
+
+	// setup returns a service over memory repositories holding a Berlin user opted in to
+	// reminders with a 07:30 weekday reminder, with a clock the test moves
+	setup := func() (*services.ReminderService, *repository.Repositories, *models.UserPreferences, *recordingDispatcher, *time.Time) {
+		repos := memory.NewRepositories()
+		user := testutils.CreateTestUser("user_123")
+		require.NoError(t, repos.Users.Create(ctx, user))
+
+		preferences := models.DefaultPreferences(user.ID)
+		preferences.Timezone = "Europe/Berlin"
+		preferences.RemindersEnabled = true
+		require.NoError(t, repos.Preferences.Save(ctx, preferences))
+
+		require.NoError(t, repos.Reminders.Create(ctx, &models.Reminder{
+			UserID:    user.ID,
+			Days:      []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+			LocalTime: "07:30",
+			Enabled:   true,
+		}))
+
+		dispatcher := &recordingDispatcher{}
+		now := dueAt.Add(time.Minute)
+		reminders := services.NewReminderServiceWithClock(repos, dispatcher, func() time.Time { return now })
+
+		return reminders, repos, preferences, dispatcher, &now
+	}
+
+	meditate := func(t *testing.T, repos *repository.Repositories, userID string, at time.Time) {
+		session := testutils.CreateTestSession(userID)
+		session.CreatedAt = at
+		require.NoError(t, repos.Sessions.Create(ctx, session))
+	}
+
+	t.Run("send a due reminder once", func(t *testing.T) {
+		reminders, _, preferences, dispatcher, _ := setup()
+
+		sent, err := reminders.SendDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		sent, err = reminders.SendDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+
+		require.Len(t, dispatcher.sent, 1)
+		assert.Equal(t, preferences.UserID, dispatcher.sent[0].UserID)
+		assert.Equal(t, notify.KindReminder, dispatcher.sent[0].Kind)
+		assert.Equal(t, "Take 10 minutes for a mindfulness session.", dispatcher.sent[0].Body)
+	})
+
+	t.Run("return no reminders before they are due or after the window passed", func(t *testing.T) {
+		reminders, _, _, dispatcher, now := setup()
+
+		*now = dueAt.Add(-time.Minute)
+		_, err := reminders.SendDue(ctx)
+		require.NoError(t, err)
+		*now = dueAt.Add(time.Hour)
+		_, err = reminders.SendDue(ctx)
+		require.NoError(t, err)
+
+		assert.Empty(t, dispatcher.sent)
+	})
+
+	t.Run("return no reminders on days they aren't scheduled", func(t *testing.T) {
+		reminders, _, _, dispatcher, now := setup()
+
+		*now = dueAt.AddDate(0, 0, 3).Add(time.Minute)
+		_, err := reminders.SendDue(ctx)
+		require.NoError(t, err)
+
+		assert.Empty(t, dispatcher.sent)
+	})
+
+	t.Run("return no reminders when the user opted out", func(t *testing.T) {
+		reminders, repos, preferences, dispatcher, _ := setup()
+		preferences.RemindersEnabled = false
+		require.NoError(t, repos.Preferences.Save(ctx, preferences))
+
+		_, err := reminders.SendDue(ctx)
+		require.NoError(t, err)
+
+		assert.Empty(t, dispatcher.sent)
+	})
+
+	t.Run("skip a day the user already meditated in their timezone", func(t *testing.T) {
+		reminders, repos, preferences, dispatcher, _ := setup()
+		// Just after midnight in Berlin, but still the previous day in UTC
+		meditate(t, repos, preferences.UserID, time.Date(2025, 3, 12, 0, 15, 0, 0, berlin))
+
+		_, err := reminders.SendDue(ctx)
+		require.NoError(t, err)
+
+		assert.Empty(t, dispatcher.sent)
+	})
+
+	t.Run("skip reminders in quiet hours that wrap past midnight", func(t *testing.T) {
+		reminders, repos, preferences, dispatcher, _ := setup()
+		start, end := "22:00", "08:00"
+		preferences.QuietHoursStart, preferences.QuietHoursEnd = &start, &end
+		require.NoError(t, repos.Preferences.Save(ctx, preferences))
+
+		_, err := reminders.SendDue(ctx)
+		require.NoError(t, err)
+		_, err = reminders.SendDue(ctx)
+		require.NoError(t, err)
+
+		assert.Empty(t, dispatcher.sent)
+	})
+
+	t.Run("mention the streak when streak reminders are enabled", func(t *testing.T) {
+		reminders, repos, preferences, dispatcher, _ := setup()
+		preferences.StreakRemindersEnabled = true
+		require.NoError(t, repos.Preferences.Save(ctx, preferences))
+		meditate(t, repos, preferences.UserID, dueAt.AddDate(0, 0, -1))
+		meditate(t, repos, preferences.UserID, dueAt.AddDate(0, 0, -2))
+
+		_, err := reminders.SendDue(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, dispatcher.sent, 1)
+		assert.Equal(t, 2, dispatcher.sent[0].StreakDays)
+		assert.Equal(t, "Meditate today to keep your 2-day streak going.", dispatcher.sent[0].Body)
+	})
+
+	t.Run("send a reminder due just before midnight after the day changed", func(t *testing.T) {
+		reminders, repos, preferences, dispatcher, now := setup()
+		require.NoError(t, repos.Reminders.Create(ctx, &models.Reminder{
+			UserID:    preferences.UserID,
+			Days:      []string{"wednesday"},
+			LocalTime: "23:55",
+			Enabled:   true,
+		}))
+
+		*now = time.Date(2025, 3, 13, 0, 5, 0, 0, berlin)
+		_, err := reminders.SendDue(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, dispatcher.sent, 1)
+		assert.Equal(t, uint(2), dispatcher.sent[0].ReminderID)
+	})
+
+	t.Run("return an error without retrying when dispatch fails", func(t *testing.T) {
+		reminders, _, _, dispatcher, _ := setup()
+		dispatcher.err = errors.New("push service unavailable")
+
+		_, err := reminders.SendDue(ctx)
+		require.Error(t, err)
+
+		dispatcher.err = nil
+		_, err = reminders.SendDue(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, dispatcher.sent)
+	})
+}
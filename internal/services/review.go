@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
+)
+
+type MonthSummary struct {
+	Month   string `json:"month"`
+	Minutes int    `json:"minutes"`
+}
+
+type WeekdaySummary struct {
+	Weekday string `json:"weekday"`
+	Minutes int    `json:"minutes"`
+}
+
+type SessionTypeSummary struct {
+	SessionType string `json:"session_type"`
+	Sessions    int    `json:"sessions"`
+	Minutes     int    `json:"minutes"`
+}
+
+type TagSummary struct {
+	Tag      string `json:"tag"`
+	Sessions int    `json:"sessions"`
+	Minutes  int    `json:"minutes"`
+}
+
+// MoodSummary compares how users felt before and after the sessions they rated both times, on the 1 to 5 scale
+type MoodSummary struct {
+	RatedSessions int     `json:"rated_sessions"`
+	AverageBefore float64 `json:"average_before"`
+	AverageAfter  float64 `json:"average_after"`
+	// AverageChange is positive when sessions lifted the user's mood
+	AverageChange float64 `json:"average_change"`
+}
+
+// reviewTopTags is how many of the most used tags a year review lists
+const reviewTopTags = 5
+
+type YearReview struct {
+	Year                 int                 `json:"year"`
+	TotalSessions        int                 `json:"total_sessions"`
+	TotalMinutes         int                 `json:"total_minutes"`
+	TotalHours           float64             `json:"total_hours"`
+	DaysMeditated        int                 `json:"days_meditated"`
+	BusiestMonth         *MonthSummary       `json:"busiest_month"`
+	BusiestWeekday       *WeekdaySummary     `json:"busiest_weekday"`
+	FavouriteSessionType *SessionTypeSummary `json:"favourite_session_type"`
+	LongestStreak        StreakRecord        `json:"longest_streak"`
+	LongestSession       *models.Session     `json:"longest_session"`
+	TopTags              []TagSummary        `json:"top_tags"`
+	MoodImprovement      *MoodSummary        `json:"mood_improvement"`
+	GeneratedAt          time.Time           `json:"generated_at"`
+}
+
+// ReviewService generates and caches year-in-review summaries
+type ReviewService struct {
+	sessions    repository.SessionRepository
+	yearReviews repository.YearReviewRepository
+	preferences repository.PreferencesRepository
+}
+
+func NewReviewService(sessions repository.SessionRepository, yearReviews repository.YearReviewRepository, preferences repository.PreferencesRepository) *ReviewService {
+	return &ReviewService{sessions: sessions, yearReviews: yearReviews, preferences: preferences}
+}
+
+// GetYearReview returns the user's year-in-review summary, generating and caching it on first request
+func (s *ReviewService) GetYearReview(ctx context.Context, userID string, year int) (*YearReview, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.GetYearReview")
+	defer span.End()
+
+	cached, err := s.yearReviews.Find(ctx, userID, year)
+	if err == nil {
+		var review YearReview
+		if err := json.Unmarshal([]byte(cached.Summary), &review); err != nil {
+			return nil, err
+		}
+
+		return &review, nil
+	}
+
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	review, err := s.GenerateYearReview(ctx, userID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := json.Marshal(review)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.yearReviews.Save(ctx, &models.YearReview{
+		UserID:      userID,
+		Year:        year,
+		Summary:     string(summary),
+		GeneratedAt: review.GeneratedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// InvalidateYearReview drops the cached review of the year a session created at createdAt counts
+// towards in the user's timezone, so it is regenerated on next request
+func (s *ReviewService) InvalidateYearReview(ctx context.Context, userID string, createdAt time.Time) error {
+	ctx, span := tracing.Start(ctx, "ReviewService.InvalidateYearReview")
+	defer span.End()
+
+	cal, err := loadCalendar(ctx, s.preferences, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.yearReviews.Delete(ctx, userID, createdAt.In(cal.loc).Year())
+}
+
+// InvalidateYearReviews drops every cached review of the user, e.g. after their timezone changed
+func (s *ReviewService) InvalidateYearReviews(ctx context.Context, userID string) error {
+	ctx, span := tracing.Start(ctx, "ReviewService.InvalidateYearReviews")
+	defer span.End()
+
+	return s.yearReviews.DeleteForUser(ctx, userID)
+}
+
+// GenerateYearReview computes a fresh year-in-review summary without touching the cache. The
+// year, its months and weekdays follow the user's timezone.
+func (s *ReviewService) GenerateYearReview(ctx context.Context, userID string, year int) (*YearReview, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.GenerateYearReview")
+	defer span.End()
+
+	cal, err := loadCalendar(ctx, s.preferences, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, cal.loc)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+
+	totals, err := s.sessions.DailyTotals(ctx, userID, yearStart, yearEnd, cal.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	typeTotals, err := s.sessions.TypeTotals(ctx, userID, yearStart, yearEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	longest, err := longestSession(ctx, s.sessions, userID, yearStart, yearEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals, err := s.sessions.TagTotals(ctx, userID, yearStart, yearEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	moodTotals, err := s.sessions.MoodTotals(ctx, userID, yearStart, yearEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &YearReview{
+		Year:                 year,
+		DaysMeditated:        len(totals),
+		BusiestMonth:         busiestMonth(totals),
+		BusiestWeekday:       busiestWeekday(totals),
+		FavouriteSessionType: favouriteSessionType(typeTotals),
+		LongestStreak:        longestStreakRecord(totals),
+		LongestSession:       longest,
+		TopTags:              topTags(tagTotals),
+		MoodImprovement:      moodImprovement(moodTotals),
+		GeneratedAt:          time.Now().UTC(),
+	}
+
+	var totalSeconds int
+	for _, total := range totals {
+		review.TotalSessions += total.Sessions
+		totalSeconds += total.Seconds
+	}
+	review.TotalMinutes = totalSeconds / 60
+	review.TotalHours = float64(totalSeconds) / 3600.0
+
+	return review, nil
+}
+
+// busiestMonth returns the month with the most meditation time, or nil if there is none
+func busiestMonth(totals []repository.DailyTotal) *MonthSummary {
+	var secondsByMonth [12]int
+	for _, total := range totals {
+		secondsByMonth[total.Day.Month()-1] += total.Seconds
+	}
+
+	best := -1
+	for month, seconds := range secondsByMonth {
+		if seconds > 0 && (best < 0 || seconds > secondsByMonth[best]) {
+			best = month
+		}
+	}
+
+	if best < 0 {
+		return nil
+	}
+
+	return &MonthSummary{
+		Month:   time.Month(best + 1).String(),
+		Minutes: secondsByMonth[best] / 60,
+	}
+}
+
+// busiestWeekday returns the weekday with the most meditation time, or nil if there is none
+func busiestWeekday(totals []repository.DailyTotal) *WeekdaySummary {
+	var secondsByWeekday [7]int
+	for _, total := range totals {
+		secondsByWeekday[total.Day.Weekday()] += total.Seconds
+	}
+
+	best := -1
+	for weekday, seconds := range secondsByWeekday {
+		if seconds > 0 && (best < 0 || seconds > secondsByWeekday[best]) {
+			best = weekday
+		}
+	}
+
+	if best < 0 {
+		return nil
+	}
+
+	return &WeekdaySummary{
+		Weekday: time.Weekday(best).String(),
+		Minutes: secondsByWeekday[best] / 60,
+	}
+}
+
+// favouriteSessionType returns the most practised session type, or nil if there is none
+func favouriteSessionType(totals []repository.TypeTotal) *SessionTypeSummary {
+	if len(totals) == 0 {
+		return nil
+	}
+
+	return &SessionTypeSummary{
+		SessionType: totals[0].SessionType,
+		Sessions:    totals[0].Sessions,
+		Minutes:     totals[0].Seconds / 60,
+	}
+}
+
+// topTags returns the most used tags, at most reviewTopTags of them
+func topTags(totals []repository.TagTotal) []TagSummary {
+	tags := make([]TagSummary, 0, min(len(totals), reviewTopTags))
+	for _, total := range totals[:min(len(totals), reviewTopTags)] {
+		tags = append(tags, TagSummary{
+			Tag:      total.Tag,
+			Sessions: total.Sessions,
+			Minutes:  total.Seconds / 60,
+		})
+	}
+
+	return tags
+}
+
+// moodImprovement averages the mood ratings, or returns nil if no session was rated before and after
+func moodImprovement(totals repository.MoodTotals) *MoodSummary {
+	if totals.Sessions == 0 {
+		return nil
+	}
+
+	// Averages are rounded to one decimal place
+	average := func(sum int) float64 {
+		return math.Round(float64(sum)/float64(totals.Sessions)*10) / 10
+	}
+
+	return &MoodSummary{
+		RatedSessions: totals.Sessions,
+		AverageBefore: average(totals.MoodBefore),
+		AverageAfter:  average(totals.MoodAfter),
+		AverageChange: average(totals.MoodAfter - totals.MoodBefore),
+	}
+}
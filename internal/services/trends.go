@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
+)
+
+// Number of earlier periods averaged when comparing against the rolling average
+const (
+	rollingWeeks  = 4
+	rollingMonths = 3
+)
+
+// Relative change, in percent, below which a difference is not worth an insight
+const insightChangeThreshold = 10
+
+type PeriodStats struct {
+	Days                  int     `json:"days"`
+	Minutes               int     `json:"minutes"`
+	Sessions              int     `json:"sessions"`
+	ConsistencyPercentage float64 `json:"consistency_percentage"`
+}
+
+type PeriodComparison struct {
+	Period                  string      `json:"period"`
+	Current                 PeriodStats `json:"current"`
+	Previous                PeriodStats `json:"previous"`
+	RollingAverage          PeriodStats `json:"rolling_average"`
+	MinutesChangePercent    *float64    `json:"minutes_change_percent"`
+	SessionsChangePercent   *float64    `json:"sessions_change_percent"`
+	MinutesVsAveragePercent *float64    `json:"minutes_vs_average_percent"`
+}
+
+type Insight struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+type Trends struct {
+	Week     PeriodComparison `json:"week"`
+	Month    PeriodComparison `json:"month"`
+	Insights []Insight        `json:"insights"`
+}
+
+// GetTrends compares the current week and month so far against the same days of the previous
+// ones and the rolling average
+func (s *AnalyticsService) GetTrends(ctx context.Context, userID string) (*Trends, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetTrends")
+	defer span.End()
+
+	cal, err := s.calendar(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getTrends(ctx, userID, cal)
+}
+
+func (s *AnalyticsService) getTrends(ctx context.Context, userID string, cal calendar) (*Trends, error) {
+	now := cal.now()
+	from := startOfMonth(now).AddDate(0, -rollingMonths, 0)
+	if weekFrom := cal.startOfWeek(now).AddDate(0, 0, -7*rollingWeeks); weekFrom.Before(from) {
+		from = weekFrom
+	}
+
+	totals, err := s.sessions.DailyTotals(ctx, userID, from, time.Time{}, cal.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	streaks, err := s.calculateStreaks(ctx, userID, cal)
+	if err != nil {
+		return nil, err
+	}
+
+	trends := buildTrends(totals, now, cal.weekStart)
+	trends.Insights = generateInsights(trends, streaks)
+
+	return trends, nil
+}
+
+// buildTrends buckets daily totals into the current, previous and rolling week and month windows
+func buildTrends(totals []repository.DailyTotal, now time.Time, firstWeekday time.Weekday) *Trends {
+	today := startOfDay(now)
+
+	return &Trends{
+		Week: comparePeriods("week", totals, today, startOfWeek(now, firstWeekday), rollingWeeks, func(start time.Time, n int) time.Time {
+			return start.AddDate(0, 0, 7*n)
+		}),
+		Month: comparePeriods("month", totals, today, startOfMonth(now), rollingMonths, func(start time.Time, n int) time.Time {
+			return start.AddDate(0, n, 0)
+		}),
+	}
+}
+
+// comparePeriods compares the current period up to today with the same number of days at the
+// start of each earlier period, so a week that has just begun isn't measured against whole
+// weeks. An earlier period shorter than that, like February, counts in full. shift moves a
+// period start by n periods.
+func comparePeriods(period string, totals []repository.DailyTotal, today, start time.Time, rolling int, shift func(time.Time, int) time.Time) PeriodComparison {
+	end := today.AddDate(0, 0, 1)
+	elapsed := int(math.Round(end.Sub(start).Hours() / 24))
+
+	earlier := func(n int) PeriodStats {
+		from := shift(start, -n)
+		to := from.AddDate(0, 0, elapsed)
+		if next := shift(start, 1-n); to.After(next) {
+			to = next
+		}
+
+		return periodStats(totals, from, to)
+	}
+
+	comparison := PeriodComparison{
+		Period:   period,
+		Current:  periodStats(totals, start, end),
+		Previous: earlier(1),
+	}
+
+	var periods []PeriodStats
+	for i := 1; i <= rolling; i++ {
+		periods = append(periods, earlier(i))
+	}
+	comparison.RollingAverage = averageStats(periods)
+
+	comparison.MinutesChangePercent = percentChange(comparison.Current.Minutes, comparison.Previous.Minutes)
+	comparison.SessionsChangePercent = percentChange(comparison.Current.Sessions, comparison.Previous.Sessions)
+	comparison.MinutesVsAveragePercent = percentChange(comparison.Current.Minutes, comparison.RollingAverage.Minutes)
+
+	return comparison
+}
+
+// periodStats sums the daily totals falling in [from, to). Consistency is the share of days in the range with a session.
+func periodStats(totals []repository.DailyTotal, from, to time.Time) PeriodStats {
+	var stats PeriodStats
+	var seconds, daysMeditated int
+
+	for _, total := range totals {
+		// Session dates carry no location, compare them as calendar days in the caller's location
+		day := time.Date(total.Day.Year(), total.Day.Month(), total.Day.Day(), 0, 0, 0, 0, from.Location())
+		if day.Before(from) || !day.Before(to) {
+			continue
+		}
+
+		seconds += total.Seconds
+		stats.Sessions += total.Sessions
+		daysMeditated++
+	}
+
+	stats.Minutes = seconds / 60
+
+	stats.Days = int(math.Round(to.Sub(from).Hours() / 24))
+	if stats.Days > 0 {
+		stats.ConsistencyPercentage = roundPercent(float64(daysMeditated) / float64(stats.Days) * 100)
+	}
+
+	return stats
+}
+
+// averageStats averages a set of period stats, rounding counts to the nearest whole number
+func averageStats(periods []PeriodStats) PeriodStats {
+	if len(periods) == 0 {
+		return PeriodStats{}
+	}
+
+	var days, minutes, sessions int
+	var consistency float64
+	for _, period := range periods {
+		days += period.Days
+		minutes += period.Minutes
+		sessions += period.Sessions
+		consistency += period.ConsistencyPercentage
+	}
+
+	count := float64(len(periods))
+
+	return PeriodStats{
+		Days:                  int(math.Round(float64(days) / count)),
+		Minutes:               int(math.Round(float64(minutes) / count)),
+		Sessions:              int(math.Round(float64(sessions) / count)),
+		ConsistencyPercentage: roundPercent(consistency / count),
+	}
+}
+
+// percentChange returns the relative change from previous to current, or nil when there is no baseline
+func percentChange(current, previous int) *float64 {
+	if previous == 0 {
+		return nil
+	}
+
+	change := roundPercent(float64(current-previous) / float64(previous) * 100)
+
+	return &change
+}
+
+// roundPercent rounds a percentage to one decimal place
+func roundPercent(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// generateInsights turns the trend numbers into short human-readable observations
+func generateInsights(trends *Trends, streaks StreakInfo) []Insight {
+	insights := []Insight{}
+	week := trends.Week
+	month := trends.Month
+
+	switch {
+	case week.MinutesChangePercent != nil && *week.MinutesChangePercent >= insightChangeThreshold:
+		insights = append(insights, Insight{
+			Type:    "weekly_increase",
+			Message: fmt.Sprintf("You meditated %.0f%% more this week than at this point last week", *week.MinutesChangePercent),
+		})
+	case week.MinutesChangePercent != nil && *week.MinutesChangePercent <= -insightChangeThreshold && week.Current.Minutes > 0:
+		insights = append(insights, Insight{
+			Type:    "weekly_decrease",
+			Message: fmt.Sprintf("You meditated %.0f%% less this week than at this point last week", -*week.MinutesChangePercent),
+		})
+	case week.Previous.Minutes == 0 && week.Current.Minutes > 0:
+		insights = append(insights, Insight{
+			Type:    "weekly_return",
+			Message: fmt.Sprintf("Welcome back! You've meditated %d minutes this week", week.Current.Minutes),
+		})
+	case week.Current.Sessions == 0 && week.Previous.Sessions > 0:
+		insights = append(insights, Insight{
+			Type:    "weekly_nudge",
+			Message: "You haven't meditated yet this week, a few minutes today keeps the habit going",
+		})
+	}
+
+	if month.MinutesVsAveragePercent != nil && *month.MinutesVsAveragePercent >= insightChangeThreshold {
+		insights = append(insights, Insight{
+			Type:    "monthly_above_average",
+			Message: fmt.Sprintf("This month is %.0f%% ahead of your %d-month average at this point", *month.MinutesVsAveragePercent, rollingMonths),
+		})
+	}
+
+	// Only worth calling out once a few days of the week have passed
+	if week.Current.Days >= 3 && week.Current.ConsistencyPercentage >= 100 {
+		insights = append(insights, Insight{
+			Type:    "perfect_week",
+			Message: "You've meditated every day this week",
+		})
+	}
+
+	if streaks.Current >= 3 {
+		message := fmt.Sprintf("You're on a %d-day streak, keep it going", streaks.Current)
+		if streaks.Current >= streaks.Longest {
+			message = fmt.Sprintf("Your %d-day streak is your longest ever", streaks.Current)
+		}
+
+		insights = append(insights, Insight{
+			Type:    "streak",
+			Message: message,
+		})
+	}
+
+	return insights
+}
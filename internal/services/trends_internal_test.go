@@ -0,0 +1,133 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/repository"
+)
+
+// dailyTotals returns a total of minutes for each day, one session per day
+func dailyTotals(minutes int, days ...time.Time) []repository.DailyTotal {
+	totals := make([]repository.DailyTotal, 0, len(days))
+	for _, day := range days {
+		totals = append(totals, repository.DailyTotal{Day: day, Seconds: minutes * 60, Sessions: 1})
+	}
+
+	return totals
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func insightTypes(insights []Insight) []string {
+	types := make([]string, 0, len(insights))
+	for _, insight := range insights {
+		types = append(types, insight.Type)
+	}
+
+	return types
+}
+
+func TestBuildTrends(t *testing.T) {
+	// A Wednesday, three days into a week starting on Monday
+	now := time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+
+	t.Run("compare a partial week with the same days of last week", func(t *testing.T) {
+		// Every day last week, and every day so far this week
+		var days []time.Time
+		for day := date(2026, time.October, 5); day.Before(date(2026, time.October, 15)); day = day.AddDate(0, 0, 1) {
+			days = append(days, day)
+		}
+
+		trends := buildTrends(dailyTotals(10, days...), now, time.Monday)
+
+		assert.Equal(t, PeriodStats{Days: 3, Minutes: 30, Sessions: 3, ConsistencyPercentage: 100}, trends.Week.Current)
+		assert.Equal(t, PeriodStats{Days: 3, Minutes: 30, Sessions: 3, ConsistencyPercentage: 100}, trends.Week.Previous)
+		require.NotNil(t, trends.Week.MinutesChangePercent)
+		assert.Equal(t, 0.0, *trends.Week.MinutesChangePercent)
+		assert.NotContains(t, insightTypes(generateInsights(trends, StreakInfo{})), "weekly_decrease")
+	})
+
+	t.Run("ignore sessions later in earlier weeks", func(t *testing.T) {
+		trends := buildTrends(dailyTotals(10, date(2026, time.October, 12), date(2026, time.October, 10), date(2026, time.October, 3)), now, time.Monday)
+
+		assert.Equal(t, 10, trends.Week.Current.Minutes)
+		assert.Equal(t, 0, trends.Week.Previous.Minutes)
+		assert.Equal(t, 0, trends.Week.RollingAverage.Minutes)
+		assert.Nil(t, trends.Week.MinutesChangePercent)
+	})
+
+	t.Run("report a decrease against the same days of last week", func(t *testing.T) {
+		totals := dailyTotals(10, date(2026, time.October, 5), date(2026, time.October, 6), date(2026, time.October, 7), date(2026, time.October, 12))
+
+		trends := buildTrends(totals, now, time.Monday)
+
+		require.NotNil(t, trends.Week.MinutesChangePercent)
+		assert.Equal(t, -66.7, *trends.Week.MinutesChangePercent)
+		assert.Contains(t, insightTypes(generateInsights(trends, StreakInfo{})), "weekly_decrease")
+	})
+
+	t.Run("count a shorter previous month in full", func(t *testing.T) {
+		endOfMarch := time.Date(2026, time.March, 31, 10, 0, 0, 0, time.UTC)
+
+		trends := buildTrends(dailyTotals(10, date(2026, time.February, 28), date(2026, time.March, 31)), endOfMarch, time.Monday)
+
+		assert.Equal(t, 31, trends.Month.Current.Days)
+		assert.Equal(t, 28, trends.Month.Previous.Days)
+		assert.Equal(t, 10, trends.Month.Previous.Minutes)
+	})
+}
+
+func TestGenerateInsights(t *testing.T) {
+	percent := func(value float64) *float64 {
+		return &value
+	}
+
+	t.Run("welcome a user back after a week off", func(t *testing.T) {
+		trends := &Trends{Week: PeriodComparison{Current: PeriodStats{Days: 2, Minutes: 15, Sessions: 1}}}
+
+		insights := generateInsights(trends, StreakInfo{})
+
+		assert.Equal(t, []Insight{{Type: "weekly_return", Message: "Welcome back! You've meditated 15 minutes this week"}}, insights)
+	})
+
+	t.Run("nudge a user who hasn't meditated yet this week", func(t *testing.T) {
+		trends := &Trends{Week: PeriodComparison{Current: PeriodStats{Days: 1}, Previous: PeriodStats{Days: 1, Minutes: 10, Sessions: 1}}}
+
+		insights := generateInsights(trends, StreakInfo{})
+
+		assert.Equal(t, []string{"weekly_nudge"}, insightTypes(insights))
+	})
+
+	t.Run("call out a month ahead of the average, a perfect week and the longest streak", func(t *testing.T) {
+		trends := &Trends{
+			Week: PeriodComparison{
+				Current:              PeriodStats{Days: 3, Minutes: 30, Sessions: 3, ConsistencyPercentage: 100},
+				Previous:             PeriodStats{Days: 3, Minutes: 30, Sessions: 3, ConsistencyPercentage: 100},
+				MinutesChangePercent: percent(0),
+			},
+			Month: PeriodComparison{MinutesVsAveragePercent: percent(25)},
+		}
+
+		insights := generateInsights(trends, StreakInfo{Current: 10, Longest: 10})
+
+		assert.Equal(t, []Insight{
+			{Type: "monthly_above_average", Message: "This month is 25% ahead of your 3-month average at this point"},
+			{Type: "perfect_week", Message: "You've meditated every day this week"},
+			{Type: "streak", Message: "Your 10-day streak is your longest ever"},
+		}, insights)
+	})
+
+	t.Run("skip a perfect week until a few days have passed", func(t *testing.T) {
+		trends := &Trends{Week: PeriodComparison{Current: PeriodStats{Days: 2, Minutes: 20, Sessions: 2, ConsistencyPercentage: 100}}}
+
+		insights := generateInsights(trends, StreakInfo{Current: 2, Longest: 5})
+
+		assert.Equal(t, []string{"weekly_return"}, insightTypes(insights))
+	})
+}
@@ -1,7 +1,9 @@
 package testutils
 
 import (
+	"context"
 	"log"
+	"os"
 	"testing"
 
 	"gorm.io/driver/postgres"
@@ -9,10 +11,18 @@ import (
 	"gorm.io/gorm/logger"
 
 	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
-	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/database"
+	"github.com/mindful-minutes/mindful-minutes-api/migrations"
 )
 
+// SetupTestDB connects to the test database and migrates the schema. Tests are skipped when
+// DATABASE_URL is unset, so only runs that ask for a database need one; once it is set, an
+// unreachable database fails the test instead of hiding it.
 func SetupTestDB(t *testing.T) *gorm.DB {
+	if os.Getenv("DATABASE_URL") == "" {
+		t.Skip("Skipping test, DATABASE_URL is not set")
+	}
+
 	// Load config to get test database URL
 	cfg, err := config.Load()
 	if err != nil {
@@ -26,15 +36,21 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 	}
 
 	db, err := gorm.Open(postgres.Open(testDBURL), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+		NowFunc:        database.NowUTC,
 	})
 	if err != nil {
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
 
-	// Auto-migrate the schema
-	err = db.AutoMigrate(&models.User{}, &models.Session{})
+	// Apply the same embedded migrations the server runs
+	migrator, err := database.NewMigrator(db, migrations.FS)
 	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	if err := migrator.Up(context.Background()); err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
 
@@ -43,6 +59,13 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 
 func CleanupTestDB(t *testing.T, db *gorm.DB) {
 	// Clean up test data
+	db.Exec("DELETE FROM webhook_events")
+	db.Exec("DELETE FROM audit_events")
+	db.Exec("DELETE FROM user_preferences")
+	db.Exec("DELETE FROM reminders")
+	db.Exec("DELETE FROM access_tokens")
+	db.Exec("DELETE FROM year_reviews")
+	db.Exec("DELETE FROM user_achievements")
 	db.Exec("DELETE FROM sessions")
 	db.Exec("DELETE FROM users")
 
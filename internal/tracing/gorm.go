@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+// GormPlugin opens a client span around every GORM statement. Only the SQL text is recorded,
+// never the bound values, so user data stays out of traces.
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", startGormSpan("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", endGormSpan),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", startGormSpan("select")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", endGormSpan),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", startGormSpan("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", endGormSpan),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", startGormSpan("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", endGormSpan),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", startGormSpan("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", endGormSpan),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", startGormSpan("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", endGormSpan),
+	)
+}
+
+func startGormSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "postgresql"),
+				attribute.String("db.operation.name", operation),
+			),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func endGormSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.collection.name", db.Statement.Table),
+		attribute.String("db.query.text", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
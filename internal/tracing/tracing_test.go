@@ -0,0 +1,91 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/mindful-minutes/mindful-minutes-api/internal/config"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/models"
+	"github.com/mindful-minutes/mindful-minutes-api/internal/tracing"
+)
+
+// recordSpans installs a tracer provider that keeps finished spans in memory
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("return no-op shutdown when tracing is disabled", func(t *testing.T) {
+		shutdown, err := tracing.Setup(context.Background(), config.TracingConfig{Exporter: "none"}, nil)
+
+		assert.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("write spans to stdout exporter on shutdown", func(t *testing.T) {
+		previous := otel.GetTracerProvider()
+		t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+		var buf bytes.Buffer
+		shutdown, err := tracing.Setup(context.Background(), config.TracingConfig{
+			Exporter:    "stdout",
+			ServiceName: "test-service",
+			SampleRatio: 1,
+		}, &buf)
+		assert.NoError(t, err)
+
+		_, span := tracing.Start(context.Background(), "test.operation")
+		span.End()
+
+		assert.NoError(t, shutdown(context.Background()))
+		assert.Contains(t, buf.String(), "test.operation")
+		assert.Contains(t, buf.String(), "test-service")
+	})
+}
+
+func TestGormPlugin(t *testing.T) {
+	t.Run("record a span per statement as a child of the caller", func(t *testing.T) {
+		recorder := recordSpans(t)
+
+		// Dry run builds SQL and runs callbacks without needing a database
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+			DryRun:               true,
+			DisableAutomaticPing: true,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, db.Use(tracing.GormPlugin{}))
+
+		ctx, parent := tracing.Start(context.Background(), "parent")
+		var sessions []models.Session
+		db.WithContext(ctx).Where("user_id = ?", "user_secret").Find(&sessions)
+		parent.End()
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 2)
+
+		query := spans[0]
+		assert.Equal(t, "gorm.select", query.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+
+		attrs := map[string]string{}
+		for _, attr := range query.Attributes() {
+			attrs[string(attr.Key)] = attr.Value.Emit()
+		}
+		assert.Equal(t, "sessions", attrs["db.collection.name"])
+		assert.Contains(t, attrs["db.query.text"], "user_id = $1")
+		assert.NotContains(t, attrs["db.query.text"], "user_secret")
+	})
+}
@@ -0,0 +1,2 @@
+-- Drop user achievements table
+DROP TABLE IF EXISTS user_achievements;
@@ -0,0 +1,12 @@
+-- Create user achievements table
+CREATE TABLE IF NOT EXISTS user_achievements (
+    id SERIAL PRIMARY KEY,
+    user_id CHAR(26) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    badge_key VARCHAR(100) NOT NULL,
+    session_id INTEGER REFERENCES sessions(id) ON DELETE SET NULL,
+    awarded_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Each badge is awarded at most once per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_achievements_user_badge ON user_achievements(user_id, badge_key);
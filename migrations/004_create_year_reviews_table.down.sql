@@ -0,0 +1,2 @@
+-- Drop year reviews table
+DROP TABLE IF EXISTS year_reviews;
@@ -0,0 +1,11 @@
+-- Create year reviews table
+CREATE TABLE IF NOT EXISTS year_reviews (
+    id SERIAL PRIMARY KEY,
+    user_id CHAR(26) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    year INTEGER NOT NULL,
+    summary JSONB NOT NULL,
+    generated_at TIMESTAMP NOT NULL
+);
+
+-- One cached review per user and year
+CREATE UNIQUE INDEX IF NOT EXISTS idx_year_reviews_user_year ON year_reviews(user_id, year);
@@ -0,0 +1,2 @@
+-- Drop webhook events table
+DROP TABLE IF EXISTS webhook_events;
@@ -0,0 +1,13 @@
+-- Create webhook events table
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id SERIAL PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    clerk_user_id VARCHAR(255),
+    payload JSONB NOT NULL,
+    error TEXT,
+    received_at TIMESTAMP NOT NULL,
+    processed_at TIMESTAMP
+);
+
+-- Create index for clerk_user_id so a user's events can be purged
+CREATE INDEX IF NOT EXISTS idx_webhook_events_clerk_user_id ON webhook_events(clerk_user_id);
@@ -0,0 +1,2 @@
+-- Drop personal access tokens table
+DROP TABLE IF EXISTS access_tokens;
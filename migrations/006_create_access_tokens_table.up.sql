@@ -0,0 +1,17 @@
+-- Create personal access tokens table
+CREATE TABLE IF NOT EXISTS access_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id CHAR(26) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(20) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    scopes JSONB NOT NULL,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Tokens are looked up by hash on every request
+CREATE UNIQUE INDEX IF NOT EXISTS idx_access_tokens_token_hash ON access_tokens(token_hash);
+
+-- Create index for user_id so a user's tokens can be listed
+CREATE INDEX IF NOT EXISTS idx_access_tokens_user_id ON access_tokens(user_id);
@@ -0,0 +1,2 @@
+-- Remove role from users
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,3 @@
+-- Add role to users; admins can use the /api/admin routes
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user'
+    CHECK (role IN ('user', 'admin'));
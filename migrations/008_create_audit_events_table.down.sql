@@ -0,0 +1,3 @@
+-- Drop audit events table and its append-only trigger function
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS reject_audit_event_update();
@@ -0,0 +1,3 @@
+-- Remove account deletion requests from users
+DROP INDEX IF EXISTS idx_users_deletion_requested_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_requested_at;
@@ -0,0 +1,5 @@
+-- Record when a user asked for their account to be deleted; it is purged after the grace period
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_requested_at TIMESTAMP;
+
+-- The purge looks for requests and soft deletes older than the grace period
+CREATE INDEX IF NOT EXISTS idx_users_deletion_requested_at ON users(deletion_requested_at);
@@ -0,0 +1,2 @@
+-- Drop user preferences table
+DROP TABLE IF EXISTS user_preferences;
@@ -0,0 +1,15 @@
+-- Create user preferences table; users without a row use the application defaults
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id CHAR(26) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    locale VARCHAR(35) NOT NULL DEFAULT 'en-US',
+    week_start VARCHAR(10) NOT NULL DEFAULT 'monday',
+    default_session_type VARCHAR(50) NOT NULL DEFAULT 'mindfulness',
+    default_duration_seconds INTEGER NOT NULL DEFAULT 600,
+    units VARCHAR(10) NOT NULL DEFAULT 'metric',
+    reminders_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    streak_reminders_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    share_anonymous_usage BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
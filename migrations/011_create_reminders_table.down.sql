@@ -0,0 +1,2 @@
+-- Drop meditation reminders table
+DROP TABLE IF EXISTS reminders;
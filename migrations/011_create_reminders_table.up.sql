@@ -0,0 +1,17 @@
+-- Create meditation reminders table
+CREATE TABLE IF NOT EXISTS reminders (
+    id SERIAL PRIMARY KEY,
+    user_id CHAR(26) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    days JSONB NOT NULL,
+    local_time CHAR(5) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_triggered_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Create index for user_id so a user's reminders can be listed
+CREATE INDEX IF NOT EXISTS idx_reminders_user_id ON reminders(user_id);
+
+-- The scheduler reads every enabled reminder each tick
+CREATE INDEX IF NOT EXISTS idx_reminders_enabled ON reminders(enabled) WHERE enabled;
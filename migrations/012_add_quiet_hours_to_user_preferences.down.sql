@@ -0,0 +1,3 @@
+-- Remove quiet hours from user preferences
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS quiet_hours_end;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS quiet_hours_start;
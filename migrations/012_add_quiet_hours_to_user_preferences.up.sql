@@ -0,0 +1,3 @@
+-- Quiet hours as local HH:MM times during which no reminders are sent; NULL means none
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS quiet_hours_start CHAR(5);
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS quiet_hours_end CHAR(5);
@@ -0,0 +1,4 @@
+-- Remove tags and mood ratings from sessions
+ALTER TABLE sessions DROP COLUMN IF EXISTS mood_after;
+ALTER TABLE sessions DROP COLUMN IF EXISTS mood_before;
+ALTER TABLE sessions DROP COLUMN IF EXISTS tags;
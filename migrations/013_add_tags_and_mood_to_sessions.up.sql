@@ -0,0 +1,9 @@
+-- Tags are the user's own labels for a session, stored as a JSON array of strings
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]';
+
+-- Mood before and after a session, rated from 1 to 5; NULL means not rated
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS mood_before SMALLINT CHECK (mood_before BETWEEN 1 AND 5);
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS mood_after SMALLINT CHECK (mood_after BETWEEN 1 AND 5);
+
+-- Cached year reviews predate tags and moods, drop them so they are regenerated with both
+DELETE FROM year_reviews;
@@ -0,0 +1,12 @@
+// Package migrations embeds the SQL schema migrations so they ship inside the server binary.
+//
+// Files are named NNN_description.up.sql and NNN_description.down.sql, where NNN is the
+// migration version. Both halves are required for every version.
+package migrations
+
+import "embed"
+
+// FS holds every migration file in this directory
+//
+//go:embed *.sql
+var FS embed.FS